@@ -0,0 +1,125 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// A source of the current time. Code that measures elapsed time should accept
+// a Clock so that tests can control the passage of time instead of sleeping.
+type Clock interface {
+	// Returns the current time.
+	Now() time.Time
+
+	// Returns a channel that receives the current time once at least d has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+// Returns a Clock that reads the system time.
+func Real() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Returns c if it is non-nil, and the real clock otherwise.
+func OrReal(c Clock) Clock {
+	if c == nil {
+		return Real()
+	}
+	return c
+}
+
+// A Clock whose time only changes when Advance or Set is called. Safe for
+// concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+var _ Clock = (*Fake)(nil)
+
+type fakeWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// Returns a fake clock set to the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// The returned channel receives a value once the clock has been advanced by at
+// least d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Buffered so that firing never blocks.
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c
+	}
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), c: c})
+	return c
+}
+
+// Moves the clock forward by d, firing any channels returned by After whose
+// deadline has been reached.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(f.now.Add(d))
+}
+
+// Sets the clock to the given time, firing any channels returned by After
+// whose deadline has been reached. The time may be moved backwards.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(now)
+}
+
+// Returns the number of channels returned by After that have not yet fired.
+// Tests can use this to wait until a goroutine is blocked on the clock.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+func (f *Fake) setLocked(now time.Time) {
+	f.now = now
+
+	remaining := f.waiters[:0]
+	for _, w := range f.waiters {
+		if !now.Before(w.deadline) {
+			w.c <- now
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+
+	// Clear the tail so that fired channels can be collected.
+	for i := len(remaining); i < len(f.waiters); i++ {
+		f.waiters[i] = fakeWaiter{}
+	}
+	f.waiters = remaining
+}
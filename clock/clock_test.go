@@ -0,0 +1,48 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+	assert.Equal(t, start, c.Now())
+
+	immediate := c.After(0)
+	later := c.After(2 * time.Second)
+	assert.Equal(t, 1, c.Waiters())
+
+	select {
+	case v := <-immediate:
+		assert.Equal(t, start, v)
+	default:
+		assert.Fail(t, "After(0) should fire immediately")
+	}
+
+	c.Advance(time.Second)
+	select {
+	case <-later:
+		assert.Fail(t, "fired too early")
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case v := <-later:
+		assert.Equal(t, start.Add(2*time.Second), v)
+	default:
+		assert.Fail(t, "should have fired")
+	}
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func TestOrReal(t *testing.T) {
+	assert.Equal(t, Real(), OrReal(nil))
+
+	fake := NewFake(time.Time{})
+	assert.Equal(t, fake, OrReal(fake))
+}
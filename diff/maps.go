@@ -0,0 +1,72 @@
+package diff
+
+import "github.com/akitasoftware/go-utils/maps"
+
+// The old and new values of a changed map entry.
+type Change[V any] struct {
+	Old V
+	New V
+}
+
+// The difference between two maps.
+type MapDiff[K comparable, V any] struct {
+	// Entries whose keys are only in the new map.
+	Added maps.Map[K, V]
+
+	// Entries whose keys are only in the old map.
+	Removed maps.Map[K, V]
+
+	// Entries whose keys are in both maps, but with different values.
+	Changed maps.Map[K, Change[V]]
+}
+
+// Returns the difference between the old and new maps.
+func Maps[K, V comparable](old, new maps.Map[K, V]) MapDiff[K, V] {
+	return MapsFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// Like Maps, but compares values using eq.
+func MapsFunc[K comparable, V any](old, new maps.Map[K, V], eq func(a, b V) bool) MapDiff[K, V] {
+	rv := MapDiff[K, V]{
+		Added:   maps.NewMap[K, V](),
+		Removed: maps.NewMap[K, V](),
+		Changed: maps.NewMap[K, Change[V]](),
+	}
+	for k, oldV := range old {
+		if newV, exists := new[k]; !exists {
+			rv.Removed.Put(k, oldV)
+		} else if !eq(oldV, newV) {
+			rv.Changed.Put(k, Change[V]{Old: oldV, New: newV})
+		}
+	}
+	for k, newV := range new {
+		if !old.ContainsKey(k) {
+			rv.Added.Put(k, newV)
+		}
+	}
+	return rv
+}
+
+// Returns true if the maps were equal.
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return d.Added.IsEmpty() && d.Removed.IsEmpty() && d.Changed.IsEmpty()
+}
+
+// Returns a new map with the removed keys taken out of m, and the added and
+// changed entries put in. Applying the difference to the old map gives the new
+// map. Does not modify m.
+func (d MapDiff[K, V]) Apply(m maps.Map[K, V]) maps.Map[K, V] {
+	rv := maps.NewMap[K, V]()
+	for k, v := range m {
+		if !d.Removed.ContainsKey(k) {
+			rv.Put(k, v)
+		}
+	}
+	for k, v := range d.Added {
+		rv.Put(k, v)
+	}
+	for k, change := range d.Changed {
+		rv.Put(k, change.New)
+	}
+	return rv
+}
@@ -0,0 +1,32 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaps(t *testing.T) {
+	old := maps.Map[string, int]{"a": 1, "b": 2, "c": 3}
+	new := maps.Map[string, int]{"b": 2, "c": 4, "d": 5}
+
+	d := Maps(old, new)
+	assert.Equal(t, maps.Map[string, int]{"d": 5}, d.Added)
+	assert.Equal(t, maps.Map[string, int]{"a": 1}, d.Removed)
+	assert.Equal(t, maps.Map[string, Change[int]]{"c": {Old: 3, New: 4}}, d.Changed)
+	assert.False(t, d.IsEmpty())
+
+	assert.Equal(t, new, d.Apply(old))
+	assert.Equal(t, 3, old["c"], "old map is not modified")
+
+	assert.True(t, Maps(old, old).IsEmpty())
+}
+
+func TestMapsFunc(t *testing.T) {
+	old := maps.Map[string, string]{"content-type": "application/json"}
+	new := maps.Map[string, string]{"content-type": "APPLICATION/JSON"}
+	assert.True(t, MapsFunc(old, new, strings.EqualFold).IsEmpty())
+	assert.False(t, Maps(old, new).IsEmpty())
+}
@@ -0,0 +1,58 @@
+package diff
+
+import "github.com/akitasoftware/go-utils/sets"
+
+// The difference between two sets.
+type SetDiff[T comparable] struct {
+	// Elements only in the new set.
+	Added sets.Set[T]
+
+	// Elements only in the old set.
+	Removed sets.Set[T]
+
+	// Elements in both sets.
+	Common sets.Set[T]
+}
+
+// Returns the difference between the old and new sets.
+func Sets[T comparable](old, new sets.Set[T]) SetDiff[T] {
+	rv := SetDiff[T]{
+		Added:   sets.NewSet[T](),
+		Removed: sets.NewSet[T](),
+		Common:  sets.NewSet[T](),
+	}
+	for elt := range old {
+		if new.Contains(elt) {
+			rv.Common.Insert(elt)
+		} else {
+			rv.Removed.Insert(elt)
+		}
+	}
+	for elt := range new {
+		if !old.Contains(elt) {
+			rv.Added.Insert(elt)
+		}
+	}
+	return rv
+}
+
+// Returns true if the sets were equal.
+func (d SetDiff[T]) IsEmpty() bool {
+	return d.Added.IsEmpty() && d.Removed.IsEmpty()
+}
+
+// Returns a new set with the removed elements taken out of s and the added
+// elements put in. Applying the difference to the old set gives the new set.
+// Does not modify s.
+func (d SetDiff[T]) Apply(s sets.Set[T]) sets.Set[T] {
+	rv := sets.NewSet[T]()
+	for elt := range s {
+		if !d.Removed.Contains(elt) {
+			rv.Insert(elt)
+		}
+	}
+	for elt := range d.Added {
+		rv.Insert(elt)
+	}
+	return rv
+}
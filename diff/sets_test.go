@@ -0,0 +1,25 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSets(t *testing.T) {
+	old := sets.NewSet("a", "b", "c")
+	new := sets.NewSet("b", "c", "d")
+
+	d := Sets(old, new)
+	assert.Equal(t, sets.NewSet("d"), d.Added)
+	assert.Equal(t, sets.NewSet("a"), d.Removed)
+	assert.Equal(t, sets.NewSet("b", "c"), d.Common)
+	assert.False(t, d.IsEmpty())
+
+	assert.Equal(t, new, d.Apply(old))
+	assert.Equal(t, sets.NewSet("a", "b", "c"), old, "old set is not modified")
+
+	assert.True(t, Sets(old, old).IsEmpty())
+	assert.True(t, Sets[string](nil, nil).IsEmpty())
+}
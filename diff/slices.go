@@ -0,0 +1,267 @@
+// Package diff computes structural differences between slices, sets and maps,
+// applies them as patches, and renders them for people to read.
+package diff
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The kind of an edit in an edit script.
+type EditKind int
+
+const (
+	// The element is in both the old and new slices.
+	Equal EditKind = iota
+
+	// The element is only in the new slice.
+	Insert
+
+	// The element is only in the old slice.
+	Delete
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case Equal:
+		return "Equal"
+	case Insert:
+		return "Insert"
+	case Delete:
+		return "Delete"
+	}
+	return "Unknown"
+}
+
+// A single step in an edit script that transforms an old slice into a new one.
+type Edit[T any] struct {
+	Kind EditKind
+
+	// The positions in the old and new slices just before this edit is
+	// applied. For Equal and Delete, OldIndex is the index of Value in the old
+	// slice; for Equal and Insert, NewIndex is the index of Value in the new
+	// slice.
+	OldIndex int
+	NewIndex int
+
+	// The element being kept, inserted or deleted. For Equal, this is the
+	// element from the new slice.
+	Value T
+}
+
+// Returns a shortest edit script that transforms old into new, using Myers'
+// O(ND) algorithm, where D is the number of insertions and deletions. The
+// script lists every element of both slices: Equal edits for the longest
+// common subsequence, interleaved with Delete and Insert edits.
+//
+// Uses the linear-space refinement of the algorithm, so memory use is
+// O(N+M) regardless of how different the slices are.
+func Slices[T comparable](old, new []T) []Edit[T] {
+	return SlicesFunc(old, new, func(a, b T) bool { return a == b })
+}
+
+// Like Slices, but compares elements using eq.
+func SlicesFunc[T any](old, new []T, eq func(a, b T) bool) []Edit[T] {
+	d := myersDiff[T]{
+		old:   old,
+		new:   new,
+		eq:    eq,
+		edits: make([]Edit[T], 0, len(old)+len(new)),
+	}
+	d.compare(0, len(old), 0, len(new))
+	return d.edits
+}
+
+// Computes edit scripts using the linear-space divide-and-conquer variant of
+// Myers, "An O(ND) Difference Algorithm and Its Variations" (1986): each
+// subproblem is split at a point on an optimal path, found by running the
+// greedy algorithm forwards from the start and backwards from the end until
+// the two meet.
+type myersDiff[T any] struct {
+	old, new []T
+	eq       func(a, b T) bool
+
+	// The edit script so far, in order.
+	edits []Edit[T]
+}
+
+// Appends the edits that transform old[oldLo:oldHi] into new[newLo:newHi].
+func (d *myersDiff[T]) compare(oldLo, oldHi, newLo, newHi int) {
+	// Common prefixes and suffixes are always kept, so strip them to reduce
+	// the size of the problem.
+	for oldLo < oldHi && newLo < newHi && d.eq(d.old[oldLo], d.new[newLo]) {
+		d.edits = append(d.edits, Edit[T]{Kind: Equal, OldIndex: oldLo, NewIndex: newLo, Value: d.new[newLo]})
+		oldLo++
+		newLo++
+	}
+	suffix := 0
+	for oldLo < oldHi-suffix && newLo < newHi-suffix &&
+		d.eq(d.old[oldHi-1-suffix], d.new[newHi-1-suffix]) {
+		suffix++
+	}
+	oldHi -= suffix
+	newHi -= suffix
+
+	switch {
+	case oldLo == oldHi:
+		for y := newLo; y < newHi; y++ {
+			d.edits = append(d.edits, Edit[T]{Kind: Insert, OldIndex: oldLo, NewIndex: y, Value: d.new[y]})
+		}
+	case newLo == newHi:
+		for x := oldLo; x < oldHi; x++ {
+			d.edits = append(d.edits, Edit[T]{Kind: Delete, OldIndex: x, NewIndex: newLo, Value: d.old[x]})
+		}
+	default:
+		// Both halves of the split have fewer edits than the whole, because
+		// the prefix and suffix have been stripped.
+		x, y := d.split(oldLo, oldHi, newLo, newHi)
+		d.compare(oldLo, x, newLo, y)
+		d.compare(x, oldHi, y, newHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.edits = append(d.edits, Edit[T]{Kind: Equal, OldIndex: oldHi + i, NewIndex: newHi + i, Value: d.new[newHi+i]})
+	}
+}
+
+// Returns a point (x, y) on a shortest path from (oldLo, newLo) to
+// (oldHi, newHi) through the edit graph, such that neither half of the path
+// is the whole path. Both ranges must be non-empty.
+func (d *myersDiff[T]) split(oldLo, oldHi, newLo, newHi int) (int, int) {
+	n, m := oldHi-oldLo, newHi-newLo
+	maxD := (n + m + 1) / 2
+	delta := n - m
+	odd := delta%2 != 0
+
+	// forward[offset+k] is the furthest x reached on diagonal k = x - y from
+	// the start; backward[offset+k] is the furthest distance reached on
+	// diagonal k from the end, measured with both slices reversed. Diagonals
+	// that leave the edit graph are skipped by narrowing the range of k.
+	offset := maxD + 1
+	forward := make([]int, 2*maxD+3)
+	backward := make([]int, 2*maxD+3)
+	for i := range forward {
+		forward[i] = -1
+		backward[i] = -1
+	}
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+	forwardStart, forwardEnd := 0, 0
+	backwardStart, backwardEnd := 0, 0
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step + forwardStart; k <= step-forwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				// Move down from diagonal k+1: an insertion.
+				x = forward[offset+k+1]
+			} else {
+				// Move right from diagonal k-1: a deletion.
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+
+			// Follow the diagonal through equal elements.
+			for x < n && y < m && d.eq(d.old[oldLo+x], d.new[newLo+y]) {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			switch {
+			case x > n:
+				forwardEnd += 2
+			case y > m:
+				forwardStart += 2
+			case odd:
+				// The backward search has taken one step fewer. On the
+				// same diagonal, it has reached x = n - backward[...].
+				kb := delta - k
+				if kb >= -(step-1) && kb <= step-1 && backward[offset+kb] >= 0 &&
+					x >= n-backward[offset+kb] {
+					return oldLo + x, newLo + y
+				}
+			}
+		}
+
+		for k := -step + backwardStart; k <= step-backwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+
+			for x < n && y < m && d.eq(d.old[oldHi-1-x], d.new[newHi-1-y]) {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			switch {
+			case x > n:
+				backwardEnd += 2
+			case y > m:
+				backwardStart += 2
+			case !odd:
+				// Both searches have taken the same number of steps.
+				kf := delta - k
+				if kf >= -step && kf <= step && forward[offset+kf] >= 0 &&
+					forward[offset+kf] >= n-x {
+					fx := forward[offset+kf]
+					return oldLo + fx, newLo + fx - kf
+				}
+			}
+		}
+	}
+
+	// Unreachable: the searches always meet within maxD steps. Deleting
+	// everything and then inserting everything is still a valid split.
+	return oldHi, newLo
+}
+
+// Applies an edit script produced by Slices or SlicesFunc to old, returning
+// the new slice. Returns an error if the script doesn't fit old, for example
+// because it was computed against a different slice. Equal and Delete edits
+// are checked against old's length and positions, but not its contents; kept
+// elements are taken from the script, so the result is exactly the new slice
+// the script was computed from.
+func Apply[T any](old []T, script []Edit[T]) ([]T, error) {
+	rv := make([]T, 0, len(script))
+	pos := 0
+	for i, e := range script {
+		if e.OldIndex != pos {
+			return nil, errors.Errorf("edit %d is at position %d of the old slice, but expected %d", i, e.OldIndex, pos)
+		}
+
+		switch e.Kind {
+		case Equal, Delete:
+			if pos >= len(old) {
+				return nil, errors.Errorf("edit %d is past the end of the old slice", i)
+			}
+			if e.Kind == Equal {
+				rv = append(rv, e.Value)
+			}
+			pos++
+		case Insert:
+			rv = append(rv, e.Value)
+		default:
+			return nil, errors.Errorf("edit %d has unknown kind %d", i, e.Kind)
+		}
+	}
+
+	if pos != len(old) {
+		return nil, errors.Errorf("edit script covers %d of %d elements of the old slice", pos, len(old))
+	}
+	return rv, nil
+}
+
+// Returns true if the script contains any insertions or deletions.
+func HasChanges[T any](script []Edit[T]) bool {
+	for _, e := range script {
+		if e.Kind != Equal {
+			return true
+		}
+	}
+	return false
+}
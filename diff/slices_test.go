@@ -0,0 +1,195 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Renders a script compactly, e.g. " a-b+x c".
+func render(script []Edit[string]) string {
+	var b strings.Builder
+	for _, e := range script {
+		switch e.Kind {
+		case Equal:
+			b.WriteString(" ")
+		case Insert:
+			b.WriteString("+")
+		case Delete:
+			b.WriteString("-")
+		}
+		b.WriteString(e.Value)
+	}
+	return b.String()
+}
+
+func TestSlices(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old, new string
+		expected string
+	}{
+		{"both empty", "", "", ""},
+		{"insert all", "", "ab", "+a+b"},
+		{"delete all", "ab", "", "-a-b"},
+		{"equal", "abc", "abc", " a b c"},
+		{"replace middle", "abc", "axc", " a-b+x c"},
+		{"insert middle", "ac", "abc", " a+b c"},
+		{"delete ends", "xabcy", "abc", "-x a b c-y"},
+		// One of several shortest scripts for the example in Myers' paper.
+		{"myers paper example", "abcabba", "cbabac", "-a+c b-c a b-b a+c"},
+	}
+
+	for _, tc := range testCases {
+		old := strings.Split(tc.old, "")
+		new := strings.Split(tc.new, "")
+		script := Slices(old, new)
+		assert.Equal(t, tc.expected, render(script), tc.name)
+		assert.Equal(t, tc.old != tc.new, HasChanges(script), tc.name)
+
+		patched, err := Apply(old, script)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, new, patched, tc.name)
+	}
+}
+
+func TestSlicesFunc(t *testing.T) {
+	old := []string{"GET /a", "POST /b"}
+	new := []string{"get /a", "PUT /b"}
+	script := SlicesFunc(old, new, strings.EqualFold)
+	assert.Equal(t, " get /a-POST /b+PUT /b", render(script))
+
+	// Kept elements come from the new slice.
+	patched, err := Apply(old, script)
+	assert.NoError(t, err)
+	assert.Equal(t, new, patched)
+}
+
+// Returns the length of the longest common subsequence, by dynamic
+// programming.
+func lcsLength(a, b []int) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] > table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func TestSlicesRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomSlice := func() []int {
+		s := make([]int, rng.Intn(30))
+		for i := range s {
+			s[i] = rng.Intn(4)
+		}
+		return s
+	}
+
+	for i := 0; i < 500; i++ {
+		old, new := randomSlice(), randomSlice()
+		script := Slices(old, new)
+		name := fmt.Sprintf("%v -> %v", old, new)
+
+		patched, err := Apply(old, script)
+		if !assert.NoError(t, err, name) {
+			return
+		}
+		assert.Equal(t, len(new), len(patched), name)
+		for j := range new {
+			assert.Equal(t, new[j], patched[j], name)
+		}
+
+		// The script is minimal: it keeps a longest common subsequence.
+		equal := 0
+		for _, e := range script {
+			if e.Kind == Equal {
+				equal++
+				assert.Equal(t, old[e.OldIndex], new[e.NewIndex], name)
+			}
+		}
+		assert.Equal(t, lcsLength(old, new), equal, name)
+		assert.Len(t, script, len(old)+len(new)-equal, name)
+	}
+}
+
+func TestSlicesLargeDisjoint(t *testing.T) {
+	const size = 4000
+	old := make([]int, size)
+	new := make([]int, size)
+	for i := range old {
+		old[i] = i
+		new[i] = size + i
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	script := Slices(old, new)
+	runtime.ReadMemStats(&after)
+
+	assert.Len(t, script, 2*size)
+	for _, e := range script {
+		assert.NotEqual(t, Equal, e.Kind)
+	}
+	patched, err := Apply(old, script)
+	assert.NoError(t, err)
+	assert.Equal(t, new, patched)
+
+	// Memory use is linear in the size of the input, rather than in the size
+	// times the number of edits.
+	allocated := after.TotalAlloc - before.TotalAlloc
+	assert.Less(t, allocated, uint64(16<<20), "allocated %d bytes", allocated)
+}
+
+func TestSlicesLargeSimilar(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	old := make([]int, 5000)
+	for i := range old {
+		old[i] = rng.Intn(1000)
+	}
+
+	// Change about one element in fifty.
+	new := make([]int, 0, len(old))
+	for _, x := range old {
+		switch rng.Intn(100) {
+		case 0:
+			// Drop x.
+		case 1:
+			new = append(new, x, rng.Intn(1000))
+		default:
+			new = append(new, x)
+		}
+	}
+
+	script := Slices(old, new)
+	patched, err := Apply(old, script)
+	assert.NoError(t, err)
+	assert.Equal(t, new, patched)
+}
+
+func TestApplyErrors(t *testing.T) {
+	script := Slices([]string{"a", "b"}, []string{"a", "c"})
+
+	_, err := Apply([]string{"a"}, script)
+	assert.Error(t, err, "old slice too short")
+
+	_, err = Apply([]string{"a", "b", "c"}, script)
+	assert.Error(t, err, "old slice too long")
+
+	_, err = Apply([]string{"a", "b"}, script[1:])
+	assert.Error(t, err, "script skips an element")
+}
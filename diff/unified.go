@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Renders an edit script in unified diff format, as produced by `diff -u`,
+// with the given number of lines of context around each change. format
+// renders a single element as a line; it should not include a newline.
+// Returns an empty string if the script has no changes.
+//
+// For example, a script that changes "b" to "x" in ["a", "b", "c"] renders as:
+//
+//	--- old
+//	+++ new
+//	@@ -1,3 +1,3 @@
+//	 a
+//	-b
+//	+x
+//	 c
+func Unified[T any](oldName, newName string, script []Edit[T], context int, format func(T) string) string {
+	if context < 0 {
+		context = 0
+	}
+
+	var b strings.Builder
+	for _, h := range hunks(script, context) {
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&b, script[h.start:h.end], format)
+	}
+	return b.String()
+}
+
+// A range of edits [start, end) to render together.
+type hunk struct {
+	start, end int
+}
+
+// Groups the changes in the script into hunks, each including up to context
+// Equal edits on either side. Hunks whose context would overlap or touch are
+// merged.
+func hunks[T any](script []Edit[T], context int) []hunk {
+	var rv []hunk
+	for i, e := range script {
+		if e.Kind == Equal {
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i + 1 + context
+		if end > len(script) {
+			end = len(script)
+		}
+
+		if len(rv) > 0 && start <= rv[len(rv)-1].end {
+			rv[len(rv)-1].end = end
+		} else {
+			rv = append(rv, hunk{start: start, end: end})
+		}
+	}
+	return rv
+}
+
+func writeHunk[T any](b *strings.Builder, edits []Edit[T], format func(T) string) {
+	oldCount, newCount := 0, 0
+	for _, e := range edits {
+		if e.Kind != Insert {
+			oldCount++
+		}
+		if e.Kind != Delete {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n",
+		hunkRange(edits[0].OldIndex, oldCount),
+		hunkRange(edits[0].NewIndex, newCount))
+
+	for _, e := range edits {
+		switch e.Kind {
+		case Equal:
+			b.WriteString(" ")
+		case Insert:
+			b.WriteString("+")
+		case Delete:
+			b.WriteString("-")
+		}
+		b.WriteString(format(e.Value))
+		b.WriteString("\n")
+	}
+}
+
+// Formats a hunk's line range. Lines are numbered from 1, and an empty range
+// is numbered by the line before it, as GNU diff does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func identity(s string) string {
+	return s
+}
+
+func lines(s string) []string {
+	return strings.Split(s, "\n")
+}
+
+func TestUnified(t *testing.T) {
+	old := lines("a\nb\nc\nd\ne\nf\ng\nh\ni\nj")
+	new := lines("a\nB\nc\nd\ne\nf\ng\nh\nj\nk")
+
+	expected := `--- old.txt
++++ new.txt
+@@ -1,4 +1,4 @@
+ a
+-b
++B
+ c
+ d
+@@ -7,4 +7,4 @@
+ g
+ h
+-i
+ j
++k
+`
+	assert.Equal(t, expected, Unified("old.txt", "new.txt", Slices(old, new), 2, identity))
+
+	// With more context, the hunks merge.
+	merged := Unified("old.txt", "new.txt", Slices(old, new), 3, identity)
+	assert.Equal(t, 1, strings.Count(merged, "@@ -"))
+	assert.Contains(t, merged, "@@ -1,10 +1,10 @@\n")
+}
+
+func TestUnifiedEdgeCases(t *testing.T) {
+	assert.Equal(t, "", Unified("a", "b", Slices(lines("x\ny"), lines("x\ny")), 3, identity))
+
+	expected := `--- a
++++ b
+@@ -0,0 +1,2 @@
++x
++y
+`
+	assert.Equal(t, expected, Unified("a", "b", Slices(nil, lines("x\ny")), 3, identity))
+
+	expected = `--- a
++++ b
+@@ -2,1 +1,0 @@
+-y
+`
+	assert.Equal(t, expected, Unified("a", "b", Slices(lines("x\ny"), lines("x")), 0, identity))
+}
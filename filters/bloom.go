@@ -0,0 +1,193 @@
+package filters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/pkg/errors"
+)
+
+// The version of the binary encoding produced by BloomFilter.MarshalBinary.
+const bloomBinaryVersion = 1
+
+// A Bloom filter: a set that supports approximate membership queries in
+// constant memory. Contains never returns false for an inserted element, but
+// may return true for an element that was never inserted.
+//
+// By default, elements are hashed with a hash function that is stable across
+// processes for strings, numbers, and structs and arrays of these, so a filter
+// of such elements can be persisted with MarshalBinary.
+type BloomFilter[T comparable] struct {
+	bits      []uint64
+	numBits   uint64
+	numHashes int
+	hasher    func(T) uint64
+}
+
+// The most hash functions a filter uses. This is enough for false-positive
+// rates far below 10^-15, and bounds the work done by a filter decoded from
+// untrusted data.
+const maxBloomHashes = 64
+
+// Returns a filter sized so that, after expectedElements distinct elements are
+// inserted, the false-positive rate is about falsePositiveRate. Panics if
+// expectedElements is not positive or falsePositiveRate is not in (0, 1).
+func NewBloomFilter[T comparable](expectedElements int, falsePositiveRate float64) *BloomFilter[T] {
+	return NewBloomFilterWithHasher(expectedElements, falsePositiveRate, hashing.Hash[T])
+}
+
+// Like NewBloomFilter, but hashes elements with the given function, which must
+// produce well-distributed 64-bit hashes. Filters that are unioned or
+// persisted must use the same function.
+func NewBloomFilterWithHasher[T comparable](expectedElements int, falsePositiveRate float64, hasher func(T) uint64) *BloomFilter[T] {
+	if expectedElements <= 0 {
+		panic("filters: BloomFilter expected elements must be positive")
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic("filters: BloomFilter false-positive rate must be in (0, 1)")
+	}
+
+	n := float64(expectedElements)
+	numBits := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	numHashes := int(math.Round(float64(numBits) / n * math.Ln2))
+	if numHashes < 1 {
+		numHashes = 1
+	} else if numHashes > maxBloomHashes {
+		numHashes = maxBloomHashes
+	}
+	return newBloomFilter(numBits, numHashes, hasher)
+}
+
+func newBloomFilter[T comparable](numBits uint64, numHashes int, hasher func(T) uint64) *BloomFilter[T] {
+	return &BloomFilter[T]{
+		bits:      make([]uint64, bloomWords(numBits)),
+		numBits:   numBits,
+		numHashes: numHashes,
+		hasher:    hasher,
+	}
+}
+
+// Returns the number of 64-bit words needed to hold numBits bits.
+func bloomWords(numBits uint64) uint64 {
+	rv := numBits / 64
+	if numBits%64 != 0 {
+		rv++
+	}
+	return rv
+}
+
+// Calls f with each of the bit positions for v.
+func (f *BloomFilter[T]) forEachPosition(v T, fn func(uint64)) {
+	// Derive the hash functions by double hashing.
+	h1 := f.hasher(v)
+	h2 := hashing.Mix(h1) | 1
+	for i := 0; i < f.numHashes; i++ {
+		fn((h1 + uint64(i)*h2) % f.numBits)
+	}
+}
+
+func (f *BloomFilter[T]) Insert(vs ...T) {
+	for _, v := range vs {
+		f.forEachPosition(v, func(pos uint64) {
+			f.bits[pos/64] |= 1 << (pos % 64)
+		})
+	}
+}
+
+// Returns true if v may have been inserted, and false if it definitely was
+// not.
+func (f *BloomFilter[T]) Contains(v T) bool {
+	rv := true
+	f.forEachPosition(v, func(pos uint64) {
+		rv = rv && f.bits[pos/64]&(1<<(pos%64)) != 0
+	})
+	return rv
+}
+
+// Adds the elements of other to this filter. Both filters must have been
+// created with the same parameters.
+func (f *BloomFilter[T]) Union(other *BloomFilter[T]) error {
+	if f.numBits != other.numBits || f.numHashes != other.numHashes {
+		return errors.Errorf("cannot union BloomFilter of %d bits and %d hashes into one of %d bits and %d hashes", other.numBits, other.numHashes, f.numBits, f.numHashes)
+	}
+
+	for i, word := range other.bits {
+		f.bits[i] |= word
+	}
+	return nil
+}
+
+// Returns the expected false-positive rate, given the fraction of bits that
+// are currently set.
+func (f *BloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, word := range f.bits {
+		for ; word != 0; word &= word - 1 {
+			set++
+		}
+	}
+	return math.Pow(float64(set)/float64(f.numBits), float64(f.numHashes))
+}
+
+// Encodes the filter's parameters and bits, but not its hash function: a
+// filter created with NewBloomFilterWithHasher must be decoded into one with
+// the same function.
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(bloomBinaryVersion)
+	writeUvarint(&buf, f.numBits)
+	writeUvarint(&buf, uint64(f.numHashes))
+	if err := binary.Write(&buf, binary.BigEndian, f.bits); err != nil {
+		return nil, errors.Wrap(err, "failed to write BloomFilter bits")
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to read BloomFilter version")
+	}
+	if version != bloomBinaryVersion {
+		return errors.Errorf("unsupported BloomFilter encoding version %d", version)
+	}
+
+	numBits, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BloomFilter size")
+	}
+	numHashes, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BloomFilter hash count")
+	}
+	if numBits == 0 {
+		return errors.New("invalid BloomFilter size 0")
+	}
+	if numHashes == 0 || numHashes > maxBloomHashes {
+		return errors.Errorf("invalid BloomFilter hash count %d", numHashes)
+	}
+	if words := bloomWords(numBits); words != uint64(r.Len())/8 || r.Len()%8 != 0 {
+		return errors.Errorf("BloomFilter of %d bits has %d bytes of data", numBits, r.Len())
+	}
+
+	// Keep the hasher the filter was created with, if any.
+	hasher := f.hasher
+	if hasher == nil {
+		hasher = hashing.Hash[T]
+	}
+	rv := newBloomFilter(numBits, int(numHashes), hasher)
+	if err := binary.Read(r, binary.BigEndian, rv.bits); err != nil {
+		return errors.Wrap(err, "failed to read BloomFilter bits")
+	}
+	*f = *rv
+	return nil
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buf.Write(scratch[:binary.PutUvarint(scratch[:], x)])
+}
@@ -0,0 +1,120 @@
+package filters
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the fraction of n elements never inserted into a filter for which
+// contains returns true.
+func falsePositiveRate(n int, contains func(string) bool) float64 {
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if contains(fmt.Sprintf("absent-%d", i)) {
+			falsePositives++
+		}
+	}
+	return float64(falsePositives) / float64(n)
+}
+
+func TestBloomFilter(t *testing.T) {
+	for _, rate := range []float64{0.1, 0.01, 0.001} {
+		f := NewBloomFilter[string](10000, rate)
+		for i := 0; i < 10000; i++ {
+			f.Insert(fmt.Sprintf("present-%d", i))
+		}
+
+		for i := 0; i < 10000; i++ {
+			if !assert.True(t, f.Contains(fmt.Sprintf("present-%d", i)), "no false negatives") {
+				break
+			}
+		}
+
+		actual := falsePositiveRate(100000, f.Contains)
+		assert.Less(t, actual, 1.5*rate, fmt.Sprintf("target rate %v", rate))
+		assert.InEpsilon(t, rate, f.EstimatedFalsePositiveRate(), 0.5, fmt.Sprintf("target rate %v", rate))
+	}
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	f1 := NewBloomFilter[int](100, 0.01)
+	f2 := NewBloomFilter[int](100, 0.01)
+	f1.Insert(1, 2)
+	f2.Insert(3)
+
+	assert.NoError(t, f1.Union(f2))
+	assert.True(t, f1.Contains(1))
+	assert.True(t, f1.Contains(3))
+
+	assert.Error(t, f1.Union(NewBloomFilter[int](1000, 0.01)))
+}
+
+func TestBloomFilterSerialization(t *testing.T) {
+	f := NewBloomFilter[int](1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Insert(i)
+	}
+
+	bs, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var deserialized BloomFilter[int]
+	assert.NoError(t, deserialized.UnmarshalBinary(bs))
+	for i := 0; i < 2000; i++ {
+		assert.Equal(t, f.Contains(i), deserialized.Contains(i), fmt.Sprintf("element %d", i))
+	}
+
+	assert.Error(t, deserialized.UnmarshalBinary(bs[:len(bs)-1]))
+}
+
+func TestBloomFilterInvalidEncoding(t *testing.T) {
+	encode := func(numBits, numHashes uint64, words int) []byte {
+		var buf bytes.Buffer
+		buf.WriteByte(bloomBinaryVersion)
+		writeUvarint(&buf, numBits)
+		writeUvarint(&buf, numHashes)
+		buf.Write(make([]byte, 8*words))
+		return buf.Bytes()
+	}
+
+	var f BloomFilter[int]
+	assert.NoError(t, f.UnmarshalBinary(encode(100, 64, 2)))
+
+	for name, data := range map[string][]byte{
+		"no bits":             encode(0, 1, 0),
+		"no hashes":           encode(100, 0, 2),
+		"too many hashes":     encode(100, 65, 2),
+		"huge hash count":     encode(100, math.MaxUint64, 2),
+		"too few words":       encode(100, 1, 1),
+		"too many words":      encode(100, 1, 3),
+		"partial word":        append(encode(100, 1, 2), 0),
+		"overflowing size":    encode(math.MaxUint64, 1, 0),
+		"unsupported version": append([]byte{bloomBinaryVersion + 1}, encode(100, 1, 2)[1:]...),
+	} {
+		assert.Error(t, f.UnmarshalBinary(data), name)
+	}
+}
+
+func TestBloomFilterWithHasher(t *testing.T) {
+	hasher := func(v string) uint64 { return hashing.Hash(strings.ToLower(v)) }
+	f := NewBloomFilterWithHasher(100, 0.01, hasher)
+	f.Insert("Hello")
+	assert.True(t, f.Contains("HELLO"))
+
+	// Decoding keeps the filter's hasher.
+	bs, err := f.MarshalBinary()
+	assert.NoError(t, err)
+	deserialized := NewBloomFilterWithHasher(1, 0.5, hasher)
+	assert.NoError(t, deserialized.UnmarshalBinary(bs))
+	assert.True(t, deserialized.Contains("hello"))
+
+	// Tiny false-positive rates don't need more than the maximum number of
+	// hash functions.
+	assert.Equal(t, maxBloomHashes, NewBloomFilter[int](10, 1e-300).numHashes)
+}
@@ -0,0 +1,267 @@
+package filters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/pkg/errors"
+)
+
+// The number of fingerprints held by each bucket of a CuckooFilter.
+const cuckooBucketSize = 4
+
+// The number of times Insert relocates fingerprints before giving up.
+const maxCuckooKicks = 500
+
+// The version of the binary encoding produced by CuckooFilter.MarshalBinary.
+const cuckooBinaryVersion = 1
+
+type cuckooBucket [cuckooBucketSize]uint16
+
+// A cuckoo filter, as described in "Cuckoo Filter: Practically Better Than
+// Bloom" (Fan et al., 2014). Like a BloomFilter, it supports approximate
+// membership queries, but it also supports deletion, and it has a fixed
+// capacity. Each element is stored as a 16-bit fingerprint, giving a
+// false-positive rate of about 0.01%.
+//
+// Only elements that have been inserted should be deleted. Deleting any other
+// element may remove the fingerprint of an inserted element that collides
+// with it.
+type CuckooFilter[T comparable] struct {
+	// A power of two in length. A zero fingerprint marks an empty slot.
+	buckets []cuckooBucket
+
+	count int
+
+	// Holds a fingerprint that could not be placed after relocating others.
+	// While it is in use, the filter is full.
+	victim      uint16
+	victimIndex uint64
+
+	// State for the xorshift generator used to choose which fingerprint to
+	// relocate.
+	kickState uint64
+
+	hasher func(T) uint64
+}
+
+// Returns a filter with room for at least capacity elements. Panics if
+// capacity is not positive.
+func NewCuckooFilter[T comparable](capacity int) *CuckooFilter[T] {
+	if capacity <= 0 {
+		panic("filters: CuckooFilter capacity must be positive")
+	}
+
+	// Inserts start failing at around 95% occupancy.
+	numBuckets := uint64(math.Ceil(float64(capacity) / cuckooBucketSize / 0.95))
+	return newCuckooFilter[T](1 << bits.Len64(numBuckets-1))
+}
+
+func newCuckooFilter[T comparable](numBuckets uint64) *CuckooFilter[T] {
+	return &CuckooFilter[T]{
+		buckets:   make([]cuckooBucket, numBuckets),
+		kickState: 0x9e3779b97f4a7c15,
+		hasher:    hashing.Hash[T],
+	}
+}
+
+// Returns the number of elements in the filter.
+func (f *CuckooFilter[T]) Size() int {
+	return f.count
+}
+
+func (f *CuckooFilter[T]) IsEmpty() bool {
+	return f.count == 0
+}
+
+// Returns the fingerprint of v and the index of its first candidate bucket.
+func (f *CuckooFilter[T]) locate(v T) (uint16, uint64) {
+	hash := f.hasher(v)
+	fingerprint := uint16(hash >> 48)
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	return fingerprint, hash & f.mask()
+}
+
+func (f *CuckooFilter[T]) mask() uint64 {
+	return uint64(len(f.buckets)) - 1
+}
+
+// Returns the other candidate bucket for a fingerprint in bucket index. This is
+// an involution, so a fingerprint can be moved without knowing its element.
+func (f *CuckooFilter[T]) altIndex(index uint64, fingerprint uint16) uint64 {
+	return (index ^ hashing.Mix(uint64(fingerprint))) & f.mask()
+}
+
+// Places the fingerprint in an empty slot of the given bucket, returning false
+// if the bucket is full.
+func (f *CuckooFilter[T]) place(index uint64, fingerprint uint16) bool {
+	bucket := &f.buckets[index]
+	for i, existing := range bucket {
+		if existing == 0 {
+			bucket[i] = fingerprint
+			return true
+		}
+	}
+	return false
+}
+
+// Removes one copy of the fingerprint from the given bucket, returning false if
+// it is not present.
+func (f *CuckooFilter[T]) remove(index uint64, fingerprint uint16) bool {
+	bucket := &f.buckets[index]
+	for i, existing := range bucket {
+		if existing == fingerprint {
+			bucket[i] = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (f *CuckooFilter[T]) bucketContains(index uint64, fingerprint uint16) bool {
+	for _, existing := range f.buckets[index] {
+		if existing == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// Inserts v into the filter. Returns false if the filter is full. Inserting the
+// same element twice stores two copies, which must be deleted separately.
+func (f *CuckooFilter[T]) Insert(v T) bool {
+	if f.victim != 0 {
+		return false
+	}
+
+	fingerprint, index := f.locate(v)
+	f.insertFingerprint(index, fingerprint)
+	f.count++
+	return true
+}
+
+func (f *CuckooFilter[T]) nextKick() uint64 {
+	f.kickState ^= f.kickState << 13
+	f.kickState ^= f.kickState >> 7
+	f.kickState ^= f.kickState << 17
+	return f.kickState
+}
+
+// Returns true if v may be in the filter, and false if it definitely is not.
+func (f *CuckooFilter[T]) Contains(v T) bool {
+	fingerprint, index := f.locate(v)
+	altIndex := f.altIndex(index, fingerprint)
+	if f.bucketContains(index, fingerprint) || f.bucketContains(altIndex, fingerprint) {
+		return true
+	}
+	return f.victim == fingerprint && (f.victimIndex == index || f.victimIndex == altIndex)
+}
+
+// Removes one copy of v from the filter. Returns false if v was not found.
+func (f *CuckooFilter[T]) Delete(v T) bool {
+	fingerprint, index := f.locate(v)
+	altIndex := f.altIndex(index, fingerprint)
+
+	switch {
+	case f.remove(index, fingerprint) || f.remove(altIndex, fingerprint):
+		f.count--
+	case f.victim == fingerprint && (f.victimIndex == index || f.victimIndex == altIndex):
+		f.victim = 0
+		f.count--
+		return true
+	default:
+		return false
+	}
+
+	// Room has been freed, so try to find a home for the victim.
+	if f.victim != 0 {
+		victim := f.victim
+		f.victim = 0
+		f.insertFingerprint(f.victimIndex, victim)
+	}
+	return true
+}
+
+// Stores a fingerprint in the given bucket or its alternate. If both are full,
+// evicts fingerprints to their alternate buckets until one finds room. If none
+// does, the last evicted fingerprint becomes the victim, and the filter is
+// full.
+func (f *CuckooFilter[T]) insertFingerprint(index uint64, fingerprint uint16) {
+	if f.place(index, fingerprint) {
+		return
+	}
+	index = f.altIndex(index, fingerprint)
+	if f.place(index, fingerprint) {
+		return
+	}
+
+	for kick := 0; kick < maxCuckooKicks; kick++ {
+		slot := f.nextKick() % cuckooBucketSize
+		fingerprint, f.buckets[index][slot] = f.buckets[index][slot], fingerprint
+		index = f.altIndex(index, fingerprint)
+		if f.place(index, fingerprint) {
+			return
+		}
+	}
+
+	f.victim = fingerprint
+	f.victimIndex = index
+}
+
+func (f *CuckooFilter[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(cuckooBinaryVersion)
+	writeUvarint(&buf, uint64(len(f.buckets)))
+	writeUvarint(&buf, uint64(f.count))
+	writeUvarint(&buf, uint64(f.victim))
+	writeUvarint(&buf, f.victimIndex)
+	if err := binary.Write(&buf, binary.BigEndian, f.buckets); err != nil {
+		return nil, errors.Wrap(err, "failed to write CuckooFilter buckets")
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *CuckooFilter[T]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to read CuckooFilter version")
+	}
+	if version != cuckooBinaryVersion {
+		return errors.Errorf("unsupported CuckooFilter encoding version %d", version)
+	}
+
+	var header [4]uint64
+	for i := range header {
+		if header[i], err = binary.ReadUvarint(r); err != nil {
+			return errors.Wrap(err, "failed to read CuckooFilter header")
+		}
+	}
+	numBuckets, count, victim, victimIndex := header[0], header[1], header[2], header[3]
+
+	if numBuckets == 0 || numBuckets&(numBuckets-1) != 0 {
+		return errors.Errorf("invalid CuckooFilter bucket count %d", numBuckets)
+	}
+	if victim > math.MaxUint16 || victimIndex >= numBuckets {
+		return errors.New("invalid CuckooFilter victim")
+	}
+	if numBuckets*cuckooBucketSize*2 != uint64(r.Len()) {
+		return errors.Errorf("CuckooFilter of %d buckets has %d bytes of data", numBuckets, r.Len())
+	}
+
+	rv := newCuckooFilter[T](numBuckets)
+	if err := binary.Read(r, binary.BigEndian, rv.buckets); err != nil {
+		return errors.Wrap(err, "failed to read CuckooFilter buckets")
+	}
+	rv.count = int(count)
+	rv.victim = uint16(victim)
+	rv.victimIndex = victimIndex
+	*f = *rv
+	return nil
+}
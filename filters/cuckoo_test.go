@@ -0,0 +1,90 @@
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	f := NewCuckooFilter[string](10000)
+	assert.True(t, f.IsEmpty())
+
+	for i := 0; i < 10000; i++ {
+		if !assert.True(t, f.Insert(fmt.Sprintf("present-%d", i)), "insert %d", i) {
+			return
+		}
+	}
+	assert.Equal(t, 10000, f.Size())
+
+	for i := 0; i < 10000; i++ {
+		if !assert.True(t, f.Contains(fmt.Sprintf("present-%d", i)), "no false negatives") {
+			break
+		}
+	}
+	assert.Less(t, falsePositiveRate(100000, f.Contains), 0.001)
+
+	// Delete every other element.
+	for i := 0; i < 10000; i += 2 {
+		assert.True(t, f.Delete(fmt.Sprintf("present-%d", i)))
+	}
+	assert.Equal(t, 5000, f.Size())
+	for i := 1; i < 10000; i += 2 {
+		if !assert.True(t, f.Contains(fmt.Sprintf("present-%d", i)), "no false negatives after delete") {
+			break
+		}
+	}
+
+	// Deleted elements are absent, except for false positives.
+	falsePositives := 0
+	for i := 0; i < 10000; i += 2 {
+		if f.Contains(fmt.Sprintf("present-%d", i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 5)
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	f := NewCuckooFilter[int](100)
+
+	inserted := 0
+	for f.Insert(inserted) {
+		inserted++
+	}
+	assert.GreaterOrEqual(t, inserted, 100)
+	assert.Equal(t, inserted, f.Size())
+
+	// Nothing is lost when the filter fills up.
+	for i := 0; i < inserted; i++ {
+		assert.True(t, f.Contains(i), fmt.Sprintf("element %d", i))
+	}
+
+	// Deleting makes room again.
+	assert.True(t, f.Delete(0))
+	assert.True(t, f.Insert(-1))
+	assert.True(t, f.Contains(-1))
+}
+
+func TestCuckooFilterSerialization(t *testing.T) {
+	f := NewCuckooFilter[int](1000)
+	for i := 0; i < 1000; i++ {
+		f.Insert(i)
+	}
+
+	bs, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var deserialized CuckooFilter[int]
+	assert.NoError(t, deserialized.UnmarshalBinary(bs))
+	assert.Equal(t, f.Size(), deserialized.Size())
+	for i := 0; i < 2000; i++ {
+		assert.Equal(t, f.Contains(i), deserialized.Contains(i), fmt.Sprintf("element %d", i))
+	}
+
+	assert.True(t, deserialized.Delete(5))
+	assert.False(t, deserialized.Contains(5))
+
+	assert.Error(t, deserialized.UnmarshalBinary(bs[:len(bs)-1]))
+}
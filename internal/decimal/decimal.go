@@ -0,0 +1,169 @@
+// Package decimal rounds floating-point numbers as decimals. A number is
+// treated as the shortest decimal that converts back to it, so that rounding
+// agrees with how the number is written: 2.345 rounds to 2.35, even though the
+// nearest float64 is slightly less than 2.345.
+package decimal
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+// Determines how a number is rounded when it lies between two representable
+// results. See math.RoundingMode, which shares these values.
+type Mode int
+
+const (
+	HalfAwayFromZero Mode = iota
+	HalfEven
+	Floor
+	Ceil
+	TowardZero
+)
+
+// Rounds x, which must be finite and non-zero, to the number of significant
+// figures returned by sigFigs, which is given the decimal exponent of x's
+// first digit. bitSize is 32 or 64, as for strconv.FormatFloat. Panics if mode
+// is not one of the Mode constants.
+//
+// Returns the significant digits of the result, without a sign or trailing
+// padding, and the decimal exponent of the first of them: 1234.5 to three
+// significant figures is ("123", 3). If x rounds to zero, digits is empty.
+// The exponent may be beyond the range of any float, in which case parsing the
+// result gives ±Inf.
+//
+// The digits are written to dst's underlying array if it has room, as with
+// append; a buffer of 32 bytes is always enough, so callers can avoid
+// allocating.
+func Round(dst []byte, x float64, bitSize int, mode Mode, sigFigs func(exp int) int) (digits []byte, exp int) {
+	if mode < HalfAwayFromZero || mode > TowardZero {
+		panic("decimal: unknown rounding mode")
+	}
+
+	// Get the shortest decimal digits that identify x, formatted as d.ddde±dd.
+	// The first digit is in the 10^exp place.
+	formatted := strconv.AppendFloat(dst[:0], math.Abs(x), 'e', -1, bitSize)
+	e := bytes.IndexByte(formatted, 'e')
+	exp = parseExponent(formatted[e+1:])
+
+	// Remove the decimal point, if any, in place.
+	digits = formatted[:e]
+	if len(digits) > 1 {
+		digits = append(digits[:1], digits[2:]...)
+	}
+
+	n := sigFigs(exp)
+	if n >= len(digits) {
+		// Already exact.
+		return digits, exp
+	}
+
+	if n < 0 {
+		// Rounding to a place above the one just before x's first digit. x is
+		// less than half a unit in that place, so only rounding away from zero
+		// gives a non-zero result: one unit in that place. Limit the exponent
+		// so that it can't overflow; anything this large parses as ±Inf.
+		if !roundsAwayFromZero(x < 0, mode) {
+			return digits[:0], exp
+		}
+		if n < exp+1-maxExponent {
+			return one(digits), maxExponent
+		}
+		return one(digits), exp - n + 1
+	}
+
+	// Split into the digits to keep and the rest. If n is zero, nothing is
+	// kept.
+	kept, rest := digits[:n], digits[n:]
+
+	if roundUpMagnitude(kept, rest, x < 0, mode) {
+		if n == 0 {
+			// The result is one unit in the place of the last digit that
+			// would have been kept.
+			return one(digits), exp + 1
+		}
+
+		if incrementDigits(kept) {
+			exp++
+		}
+	}
+	return kept, exp
+}
+
+// Parses an exponent as formatted by strconv, such as "+05" or "-324".
+func parseExponent(b []byte) int {
+	rv := 0
+	for _, c := range b[1:] {
+		rv = rv*10 + int(c-'0')
+	}
+	if b[0] == '-' {
+		return -rv
+	}
+	return rv
+}
+
+// Returns the single digit 1, reusing the non-empty buffer digits.
+func one(digits []byte) []byte {
+	digits[0] = '1'
+	return digits[:1]
+}
+
+// A decimal exponent beyond the range of any float, which parses as ±Inf.
+const maxExponent = 1 << 20
+
+// Returns whether the directed rounding mode increases the magnitude of a
+// number with the given sign. False for the modes that round to nearest.
+func roundsAwayFromZero(negative bool, mode Mode) bool {
+	switch mode {
+	case Floor:
+		return negative
+	case Ceil:
+		return !negative
+	}
+	return false
+}
+
+// Returns whether discarding the non-empty digits in rest should increase the
+// magnitude of kept. The shortest representation has no trailing zeros, so
+// rest is always non-zero.
+func roundUpMagnitude(kept, rest []byte, negative bool, mode Mode) bool {
+	switch mode {
+	case Floor, Ceil, TowardZero:
+		return roundsAwayFromZero(negative, mode)
+	}
+
+	switch {
+	case rest[0] > '5':
+		return true
+	case rest[0] < '5':
+		return false
+	case len(bytes.TrimRight(rest[1:], "0")) > 0:
+		// More than half.
+		return true
+	}
+
+	// Exactly half.
+	if mode == HalfEven {
+		return len(kept) > 0 && (kept[len(kept)-1]-'0')%2 == 1
+	}
+	return true
+}
+
+// Adds one, in place, to the given non-empty decimal digits. If the addition
+// carries into a new leading digit, the digits become 1 followed by zeros,
+// dropping the last digit, which is then zero, to keep the same number of
+// digits; and carried is true.
+func incrementDigits(digits []byte) (carried bool) {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return false
+		}
+		digits[i] = '0'
+	}
+
+	// Every digit was 9, and is now 0.
+	digits[0] = '1'
+	return true
+}
@@ -0,0 +1,107 @@
+// Package hashing provides hash functions for arbitrary comparable values.
+package hashing
+
+import (
+	"math"
+	"reflect"
+)
+
+// Returns a hash of k such that equal keys (as defined by ==) have equal
+// hashes. Pointers, channels and other reference types hash by identity, as
+// they compare by identity. Hashes of all other values are stable across
+// processes, so they may be persisted.
+func Hash[K comparable](k K) uint64 {
+	switch v := any(k).(type) {
+	case string:
+		return hashString(v)
+	case int:
+		return Mix(uint64(v))
+	case int64:
+		return Mix(uint64(v))
+	case int32:
+		return Mix(uint64(v))
+	case uint:
+		return Mix(uint64(v))
+	case uint64:
+		return Mix(v)
+	case uint32:
+		return Mix(uint64(v))
+	}
+	return hashValue(reflect.ValueOf(any(k)))
+}
+
+func hashValue(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Invalid:
+		// A nil interface.
+		return 0
+	case reflect.Bool:
+		if v.Bool() {
+			return Mix(1)
+		}
+		return Mix(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Mix(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Mix(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return combine(hashFloat(real(c)), hashFloat(imag(c)))
+	case reflect.String:
+		return hashString(v.String())
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return Mix(uint64(v.Pointer()))
+	case reflect.Interface:
+		return hashValue(v.Elem())
+	case reflect.Array:
+		var h uint64
+		for i := 0; i < v.Len(); i++ {
+			h = combine(h, hashValue(v.Index(i)))
+		}
+		return h
+	case reflect.Struct:
+		var h uint64
+		for i := 0; i < v.NumField(); i++ {
+			h = combine(h, hashValue(v.Field(i)))
+		}
+		return h
+	}
+
+	// Remaining kinds (maps, slices, funcs) are not comparable.
+	panic("hashing: cannot hash value of type " + v.Type().String())
+}
+
+func hashFloat(f float64) uint64 {
+	// +0 and -0 compare equal, so they must hash equally.
+	if f == 0 {
+		return Mix(0)
+	}
+	return Mix(math.Float64bits(f))
+}
+
+// FNV-1a.
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return Mix(h)
+}
+
+// The splitmix64 finalizer. Spreads the bits of x so that every subset of the
+// output bits is well distributed.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func combine(h, x uint64) uint64 {
+	return Mix(h*31 + x)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/akitasoftware/go-utils/maps/maptest"
 	"github.com/akitasoftware/go-utils/math"
 	"github.com/akitasoftware/go-utils/optionals"
 	"github.com/stretchr/testify/assert"
@@ -81,3 +82,7 @@ func TestComplexKeyMapJson(t *testing.T) {
 
 	assert.Equal(t, deserialized, m, "m == unmarshal(marshal(m))")
 }
+
+func TestComplexKeyMapConformance(t *testing.T) {
+	maptest.Run(t, func() maptest.Map[int, int] { return NewComplexKeyMap[int, int]() })
+}
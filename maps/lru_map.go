@@ -0,0 +1,132 @@
+package maps
+
+import (
+	"container/list"
+
+	"github.com/akitasoftware/go-utils/optionals"
+)
+
+// A map holding at most a fixed number of entries. When a new key would exceed
+// the capacity, the least recently used entry is evicted. Put, Upsert and Get
+// count as uses; Peek and ContainsKey do not.
+//
+// Not safe for concurrent use.
+type LRUMap[K comparable, V any] struct {
+	capacity int
+
+	// Entries, ordered from most to least recently used.
+	order *list.List
+
+	// Maps each key to its element in order.
+	elements Map[K, *list.Element]
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Returns a new, empty map holding at most capacity entries. Panics if capacity
+// is not positive.
+func NewLRUMap[K comparable, V any](capacity int) *LRUMap[K, V] {
+	if capacity <= 0 {
+		panic("maps: LRUMap capacity must be positive")
+	}
+	return &LRUMap[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		elements: NewMap[K, *list.Element](),
+	}
+}
+
+func (m *LRUMap[K, V]) Put(k K, v V) {
+	if elt, exists := m.elements[k]; exists {
+		elt.Value.(*lruEntry[K, V]).value = v
+		m.order.MoveToFront(elt)
+		return
+	}
+
+	if m.order.Len() >= m.capacity {
+		oldest := m.order.Back()
+		m.elements.Delete(m.order.Remove(oldest).(*lruEntry[K, V]).key)
+	}
+
+	m.elements.Put(k, m.order.PushFront(&lruEntry[K, V]{key: k, value: v}))
+}
+
+// If the key k is already in the map, its value is replaced with the result of
+// onConflict. Otherwise, the entry is added as with Put.
+func (m *LRUMap[K, V]) Upsert(k K, v V, onConflict func(v, newV V) V) {
+	if elt, exists := m.elements[k]; exists {
+		entry := elt.Value.(*lruEntry[K, V])
+		entry.value = onConflict(entry.value, v)
+		m.order.MoveToFront(elt)
+		return
+	}
+	m.Put(k, v)
+}
+
+// Returns the value associated with k, marking it as recently used.
+func (m *LRUMap[K, V]) Get(k K) optionals.Optional[V] {
+	elt, exists := m.elements[k]
+	if !exists {
+		return optionals.None[V]()
+	}
+	m.order.MoveToFront(elt)
+	return optionals.Some(elt.Value.(*lruEntry[K, V]).value)
+}
+
+// Returns the value associated with k, without marking it as recently used.
+func (m *LRUMap[K, V]) Peek(k K) optionals.Optional[V] {
+	elt, exists := m.elements[k]
+	if !exists {
+		return optionals.None[V]()
+	}
+	return optionals.Some(elt.Value.(*lruEntry[K, V]).value)
+}
+
+// Returns the value associated with the given key k, marking it as recently
+// used. If the key does not already exist in the map, the supplied function is
+// called, and the resulting value is entered into the map and returned.
+func (m *LRUMap[K, V]) GetOrComputeNoError(k K, computeValue func() V) V {
+	if elt, exists := m.elements[k]; exists {
+		m.order.MoveToFront(elt)
+		return elt.Value.(*lruEntry[K, V]).value
+	}
+	v := computeValue()
+	m.Put(k, v)
+	return v
+}
+
+func (m *LRUMap[K, V]) ContainsKey(k K) bool {
+	return m.elements.ContainsKey(k)
+}
+
+func (m *LRUMap[K, V]) Delete(k K) {
+	if elt, exists := m.elements[k]; exists {
+		m.order.Remove(elt)
+		m.elements.Delete(k)
+	}
+}
+
+func (m *LRUMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+func (m *LRUMap[K, V]) Size() int {
+	return m.order.Len()
+}
+
+// Returns the maximum number of entries the map can hold.
+func (m *LRUMap[K, V]) Capacity() int {
+	return m.capacity
+}
+
+// Returns the keys in the map, from most to least recently used.
+func (m *LRUMap[K, V]) Keys() []K {
+	rv := make([]K, 0, m.order.Len())
+	for elt := m.order.Front(); elt != nil; elt = elt.Next() {
+		rv = append(rv, elt.Value.(*lruEntry[K, V]).key)
+	}
+	return rv
+}
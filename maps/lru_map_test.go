@@ -0,0 +1,45 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/maps/maptest"
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUMap(t *testing.T) {
+	m := NewLRUMap[string, int](2)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+
+	// Get marks "a" as recently used, so "b" is evicted.
+	assert.Equal(t, optionals.Some(1), m.Get("a"))
+	m.Put("c", 3)
+	assert.Equal(t, []string{"c", "a"}, m.Keys())
+	assert.False(t, m.ContainsKey("b"))
+
+	// Peek doesn't affect the order.
+	assert.Equal(t, optionals.Some(1), m.Peek("a"))
+	m.Upsert("d", 4, func(v, newV int) int { return v + newV })
+	assert.Equal(t, []string{"d", "c"}, m.Keys())
+
+	m.Upsert("c", 10, func(v, newV int) int { return v + newV })
+	assert.Equal(t, optionals.Some(13), m.Peek("c"))
+	assert.Equal(t, []string{"c", "d"}, m.Keys())
+
+	assert.Equal(t, 4, m.GetOrComputeNoError("d", func() int { return 0 }))
+	assert.Equal(t, 5, m.GetOrComputeNoError("e", func() int { return 5 }))
+	assert.Equal(t, []string{"e", "d"}, m.Keys())
+
+	m.Delete("e")
+	assert.Equal(t, 1, m.Size())
+	assert.Equal(t, 2, m.Capacity())
+	assert.Panics(t, func() { NewLRUMap[int, int](0) })
+}
+
+func TestLRUMapConformance(t *testing.T) {
+	// Large enough that the suite never triggers eviction.
+	maptest.Run(t, func() maptest.Map[int, int] { return NewLRUMap[int, int](1000) })
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/akitasoftware/go-utils/maps/maptest"
 	"github.com/akitasoftware/go-utils/math"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,3 +26,7 @@ func TestBasicOps(t *testing.T) {
 	sort.Ints(sortedValues)
 	assert.Equal(t, []int{1, 3}, sortedValues)
 }
+
+func TestMapConformance(t *testing.T) {
+	maptest.Run(t, func() maptest.Map[int, int] { return NewMap[int, int]() })
+}
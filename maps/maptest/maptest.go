@@ -0,0 +1,132 @@
+// Package maptest provides a behavioral test suite for map implementations.
+package maptest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+// The operations exercised by the suite. Satisfied by maps.Map and
+// maps.ComplexKeyMap.
+type Map[K comparable, V any] interface {
+	Put(K, V)
+	Upsert(K, V, func(v, newV V) V)
+	Get(K) optionals.Optional[V]
+	ContainsKey(K) bool
+	Delete(K)
+	IsEmpty() bool
+	Size() int
+}
+
+// The number of random operations performed by each model-based check.
+const NumRandomOps = 500
+
+// Keys used by the model-based checks are drawn from [0, numKeys), so that
+// operations frequently collide.
+const numKeys = 50
+
+// Seeds used by the model-based checks. Fixed so that failures are
+// reproducible.
+var seeds = []int64{1, 2, 3, 4, 5}
+
+// Runs a suite of behavioral tests against a map implementation. Each call to
+// newMap must return a new, empty map.
+func Run(t *testing.T, newMap func() Map[int, int]) {
+	t.Run("Basic", func(t *testing.T) {
+		testBasic(t, newMap())
+	})
+	t.Run("Model", func(t *testing.T) {
+		for _, seed := range seeds {
+			testModel(t, newMap(), seed)
+		}
+	})
+}
+
+func add(x, y int) int {
+	return x + y
+}
+
+func testBasic(t *testing.T, m Map[int, int]) {
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, 0, m.Size())
+	assert.Equal(t, optionals.None[int](), m.Get(1))
+	assert.False(t, m.ContainsKey(1))
+
+	m.Put(1, 10)
+	assert.False(t, m.IsEmpty())
+	assert.Equal(t, 1, m.Size())
+	assert.Equal(t, optionals.Some(10), m.Get(1))
+	assert.True(t, m.ContainsKey(1))
+
+	m.Put(1, 20)
+	assert.Equal(t, 1, m.Size())
+	assert.Equal(t, optionals.Some(20), m.Get(1))
+
+	m.Upsert(1, 5, add)
+	assert.Equal(t, optionals.Some(25), m.Get(1))
+
+	m.Upsert(2, 5, add)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, optionals.Some(5), m.Get(2))
+
+	m.Delete(1)
+	m.Delete(3)
+	assert.Equal(t, 1, m.Size())
+	assert.False(t, m.ContainsKey(1))
+
+	m.Delete(2)
+	assert.True(t, m.IsEmpty())
+}
+
+// Performs a random sequence of operations on m, checking after each one that m
+// agrees with a reference map modelled as a Go map.
+func testModel(t *testing.T, m Map[int, int], seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	model := map[int]int{}
+
+	for i := 0; i < NumRandomOps; i++ {
+		label := func(msg string) string {
+			return fmt.Sprintf("seed %d, op %d: %s", seed, i, msg)
+		}
+
+		k := rng.Intn(numKeys)
+		v := rng.Intn(1000)
+		switch op := rng.Intn(4); op {
+		case 0:
+			m.Put(k, v)
+			model[k] = v
+		case 1:
+			m.Upsert(k, v, add)
+			model[k] += v
+		case 2:
+			m.Delete(k)
+			delete(model, k)
+		case 3:
+			expected := optionals.None[int]()
+			if modelV, exists := model[k]; exists {
+				expected = optionals.Some(modelV)
+			}
+			if !assert.Equal(t, expected, m.Get(k), label(fmt.Sprintf("get %d", k))) {
+				return
+			}
+		}
+
+		k = rng.Intn(numKeys)
+		_, exists := model[k]
+		if !assert.Equal(t, exists, m.ContainsKey(k), label(fmt.Sprintf("contains key %d", k))) {
+			return
+		}
+		if !assert.Equal(t, len(model), m.Size(), label("size")) {
+			return
+		}
+		assert.Equal(t, len(model) == 0, m.IsEmpty(), label("is empty"))
+	}
+
+	for k, v := range model {
+		assert.Equal(t, optionals.Some(v), m.Get(k), fmt.Sprintf("seed %d: get %d", seed, k))
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/akitasoftware/go-utils/optionals"
 	"github.com/akitasoftware/go-utils/sets"
-	"github.com/akitasoftware/go-utils/slices"
 )
 
 func getInternalMapKey(t time.Time) int64 {
@@ -117,8 +116,12 @@ func (m TimeMap[V]) Size() int {
 }
 
 func (m TimeMap[V]) Keys() []time.Time {
-	keys := m.internalMap.Keys()
-	return slices.Map(keys, getReverseKey)
+	// Avoid the slices package here, since it depends on this one.
+	rv := make([]time.Time, 0, m.internalMap.Size())
+	for key := range m.internalMap {
+		rv = append(rv, getReverseKey(key))
+	}
+	return rv
 }
 
 func (m TimeMap[V]) KeySet() sets.Set[time.Time] {
@@ -23,18 +23,32 @@ func Max[T constraints.Number](x, y T) T {
 	return y
 }
 
-// Assumes positive inputs. Adapted from
+// Returns the greatest common divisor of a and b, which is always
+// non-negative. GCD(a, 0) is |a|, and GCD(0, 0) is 0. Adapted from
 // https://en.wikipedia.org/wiki/Euclidean_algorithm#Implementations.
+//
+// The result is not representable, and wraps around, only when it would be
+// |x| for the most negative value x of a signed type, e.g. GCD(math.MinInt64,
+// 0).
 func GCD[T go_constraints.Integer](a, b T) T {
-	for b > 0 {
-		t := b
-		b = a % b
-		a = t
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
 	}
 	return a
 }
 
-// Assumes positive inputs.
+// Returns the least common multiple of a and b, which is always non-negative.
+// LCM(a, 0) is 0. Wraps around if the result overflows T; see LCMChecked.
 func LCM[T go_constraints.Integer](a, b T) T {
-	return a * (b / GCD(a, b))
+	if a == 0 || b == 0 {
+		return 0
+	}
+	rv := a / GCD(a, b) * b
+	if rv < 0 {
+		return -rv
+	}
+	return rv
 }
@@ -23,3 +23,38 @@ func TestMax(t *testing.T) {
 	assert.Equal(t, Max(2, -2), 2)
 	assert.Equal(t, Max(2.5, -2.0), 2.5)
 }
+
+func TestGCD(t *testing.T) {
+	testCases := []struct {
+		a, b, expected int
+	}{
+		{12, 18, 6},
+		{-12, 18, 6},
+		{12, -18, 6},
+		{-12, -18, 6},
+		{7, 0, 7},
+		{0, -7, 7},
+		{0, 0, 0},
+		{17, 5, 1},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, GCD(tc.a, tc.b), "GCD(%d, %d)", tc.a, tc.b)
+	}
+	assert.Equal(t, uint8(15), GCD[uint8](255, 30))
+}
+
+func TestLCM(t *testing.T) {
+	testCases := []struct {
+		a, b, expected int
+	}{
+		{4, 6, 12},
+		{-4, 6, 12},
+		{4, -6, 12},
+		{-4, -6, 12},
+		{5, 0, 0},
+		{0, 0, 0},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, LCM(tc.a, tc.b), "LCM(%d, %d)", tc.a, tc.b)
+	}
+}
@@ -0,0 +1,218 @@
+package math
+
+import (
+	"math"
+	"sort"
+)
+
+// Determines how a Histogram groups values into buckets. Buckets are indexed by
+// integers and cover positive magnitudes. Bucket i covers the half-open range
+// (lower, upper], matching the upper-inclusive convention used by Prometheus
+// and OpenTelemetry.
+//
+// The built-in schemes limit indices to the int32 range, as OpenTelemetry
+// does, so that index arithmetic can't overflow on any platform. Values
+// beyond the first or last bucket are counted in it.
+type BucketScheme interface {
+	// Returns the index of the bucket containing x, which must be positive and
+	// finite.
+	Index(x float64) int
+
+	// Returns the bounds of the bucket with the given index.
+	Bounds(index int) (lower, upper float64)
+}
+
+// Buckets of equal width: bucket i covers (i*Width, (i+1)*Width]. Width must
+// be positive and finite.
+type LinearBuckets struct {
+	Width float64
+}
+
+func (b LinearBuckets) Index(x float64) int {
+	return adjustIndex(b, x, math.Ceil(x/b.Width)-1)
+}
+
+func (b LinearBuckets) Bounds(index int) (float64, float64) {
+	return float64(index) * b.Width, float64(index+1) * b.Width
+}
+
+// Exponentially sized buckets, following OpenTelemetry's base-2 exponential
+// histogram: bucket i covers (base^i, base^(i+1)], where base is
+// 2^(2^-Scale). Each increment of Scale halves the relative width of the
+// buckets. Scale must be in [-10, 20], the range OpenTelemetry allows, which
+// keeps the indices of all positive float64s within the int32 range.
+type ExponentialBuckets struct {
+	Scale int
+}
+
+const (
+	minExponentialScale = -10
+	maxExponentialScale = 20
+)
+
+func (b ExponentialBuckets) Index(x float64) int {
+	return adjustIndex(b, x, math.Ceil(math.Ldexp(math.Log2(x), b.Scale))-1)
+}
+
+func (b ExponentialBuckets) Bounds(index int) (float64, float64) {
+	return math.Exp2(math.Ldexp(float64(index), -b.Scale)), math.Exp2(math.Ldexp(float64(index+1), -b.Scale))
+}
+
+// Buckets that group values by their first SigFigs significant figures, as
+// HdrHistogram does: each bucket's upper bound is a number with SigFigs
+// significant figures, and its width is one unit in the last of those figures.
+// For example, with two significant figures, the buckets include (9.8, 9.9],
+// (9.9, 10], (10, 11] and (11, 12]. Values are therefore recorded with a
+// bounded relative error, as by CeilToSigFigs. SigFigs must be in [1, 6]; with
+// more, the indices of all positive float64s would not fit in the int32 range.
+type SigFigBuckets struct {
+	SigFigs int
+}
+
+const maxBucketSigFigs = 6
+
+// The number of buckets in each power of ten.
+func (b SigFigBuckets) bucketsPerDecade() int {
+	return 9 * int(math.Pow10(b.SigFigs-1))
+}
+
+func (b SigFigBuckets) Index(x float64) int {
+	// x is in (10^exp, 10^(exp+1)], and its bucket's upper bound is
+	// mantissa*10^(exp+1-SigFigs), with mantissa in (10^(SigFigs-1), 10^SigFigs].
+	// Work out exp from x's binary exponent, so that subnormals are handled
+	// like any other number.
+	frac, exp2 := math.Frexp(x)
+	exp := int(math.Ceil(math.Log10(frac)+float64(exp2)*math.Log10(2))) - 1
+	mantissa := math.Ceil(scalePow10(x, b.SigFigs-1-exp))
+	minMantissa := math.Pow10(b.SigFigs - 1)
+	return adjustIndex(b, x, float64(exp)*float64(b.bucketsPerDecade())+mantissa-minMantissa-1)
+}
+
+func (b SigFigBuckets) Bounds(index int) (float64, float64) {
+	// Compute both bounds the same way, so that adjacent buckets share exact
+	// boundaries.
+	return b.lowerBound(index), b.lowerBound(index + 1)
+}
+
+func (b SigFigBuckets) lowerBound(index int) float64 {
+	perDecade := b.bucketsPerDecade()
+	exp := floorDiv(index, perDecade)
+	mantissa := index - exp*perDecade + int(math.Pow10(b.SigFigs-1))
+	return scalePow10(float64(mantissa), exp+1-b.SigFigs)
+}
+
+// Returns x*10^exp. The power of ten is applied in two halves, so that it
+// neither overflows nor underflows when x*10^exp is representable but 10^exp
+// alone is not, as for subnormal x.
+func scalePow10(x float64, exp int) float64 {
+	half := exp / 2
+	return x * math.Pow10(half) * math.Pow10(exp-half)
+}
+
+// User-defined buckets. Bucket 0 covers (0, Bounds[0]], bucket i covers
+// (Bounds[i-1], Bounds[i]], and the final bucket, with index len(Bounds),
+// covers (Bounds[len(Bounds)-1], +Inf).
+type ExplicitBuckets struct {
+	bounds []float64
+}
+
+// Returns buckets with the given upper bounds, which must be positive, finite
+// and strictly increasing. Panics otherwise.
+func NewExplicitBuckets(bounds ...float64) ExplicitBuckets {
+	for i, bound := range bounds {
+		if !(bound > 0) || math.IsInf(bound, 0) {
+			panic("math: ExplicitBuckets bounds must be positive and finite")
+		}
+		if i > 0 && !(bounds[i-1] < bound) {
+			panic("math: ExplicitBuckets bounds must be strictly increasing")
+		}
+	}
+	return ExplicitBuckets{bounds: append([]float64(nil), bounds...)}
+}
+
+func (b ExplicitBuckets) Index(x float64) int {
+	return sort.SearchFloat64s(b.bounds, x)
+}
+
+func (b ExplicitBuckets) Bounds(index int) (float64, float64) {
+	lower, upper := 0.0, math.Inf(1)
+	if index > 0 {
+		lower = b.bounds[index-1]
+	}
+	if index < len(b.bounds) {
+		upper = b.bounds[index]
+	}
+	return lower, upper
+}
+
+// Panics if the scheme's parameters would make Index or Bounds misbehave, such
+// as a zero LinearBuckets or SigFigBuckets.
+func validateBucketScheme(scheme BucketScheme) {
+	switch b := scheme.(type) {
+	case nil:
+		panic("math: histogram bucket scheme must not be nil")
+	case LinearBuckets:
+		if !(b.Width > 0) || math.IsInf(b.Width, 0) {
+			panic("math: LinearBuckets width must be positive and finite")
+		}
+	case ExponentialBuckets:
+		if b.Scale < minExponentialScale || b.Scale > maxExponentialScale {
+			panic("math: ExponentialBuckets scale must be in [-10, 20]")
+		}
+	case SigFigBuckets:
+		if b.SigFigs < 1 || b.SigFigs > maxBucketSigFigs {
+			panic("math: SigFigBuckets must have between 1 and 6 significant figures")
+		}
+	}
+}
+
+// The range of bucket indices used by the built-in schemes. The maximum is one
+// less than the int32 maximum, so that index+1 doesn't overflow on 32-bit
+// platforms.
+const (
+	minBucketIndex = math.MinInt32
+	maxBucketIndex = math.MaxInt32 - 1
+)
+
+// The most steps adjustIndex takes. Estimates are off by at most one or two,
+// so this is only reached when x is beyond the range of indices.
+const maxIndexAdjustments = 8
+
+// Corrects for floating-point error in an estimated bucket index for x, which
+// may be off by one near bucket boundaries. The estimate is clamped to the
+// range of bucket indices first, so that values too large or small to index
+// are counted in the last or first bucket.
+func adjustIndex(scheme BucketScheme, x float64, estimate float64) int {
+	var index int
+	switch {
+	case math.IsNaN(estimate):
+		index = 0
+	case estimate <= minBucketIndex:
+		index = minBucketIndex
+	case estimate >= maxBucketIndex:
+		index = maxBucketIndex
+	default:
+		index = int(estimate)
+	}
+
+	for i := 0; i < maxIndexAdjustments; i++ {
+		lower, upper := scheme.Bounds(index)
+		switch {
+		case x <= lower && index > minBucketIndex:
+			index--
+		case x > upper && index < maxBucketIndex:
+			index++
+		default:
+			return index
+		}
+	}
+	return index
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
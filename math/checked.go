@@ -0,0 +1,134 @@
+package math
+
+import (
+	"unsafe"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	go_constraints "golang.org/x/exp/constraints"
+)
+
+func isSigned[T go_constraints.Integer]() bool {
+	return ^T(0) < 0
+}
+
+// Returns the largest value representable by T.
+func maxValue[T go_constraints.Integer]() T {
+	if isSigned[T]() {
+		var zero T
+		bits := unsafe.Sizeof(zero) * 8
+		return T(1)<<(bits-1) - 1
+	}
+	return ^T(0)
+}
+
+// Returns the smallest value representable by T.
+func minValue[T go_constraints.Integer]() T {
+	if isSigned[T]() {
+		return -maxValue[T]() - 1
+	}
+	return 0
+}
+
+// Returns x + y, or None if the result overflows T.
+func AddChecked[T go_constraints.Integer](x, y T) optionals.Optional[T] {
+	rv := x + y
+	if isSigned[T]() {
+		if (x > 0 && y > 0 && rv < 0) || (x < 0 && y < 0 && rv >= 0) {
+			return optionals.None[T]()
+		}
+	} else if rv < x {
+		return optionals.None[T]()
+	}
+	return optionals.Some(rv)
+}
+
+// Returns x - y, or None if the result overflows T.
+func SubChecked[T go_constraints.Integer](x, y T) optionals.Optional[T] {
+	rv := x - y
+	if isSigned[T]() {
+		if (y > 0 && rv > x) || (y < 0 && rv < x) {
+			return optionals.None[T]()
+		}
+	} else if x < y {
+		return optionals.None[T]()
+	}
+	return optionals.Some(rv)
+}
+
+// Returns x * y, or None if the result overflows T.
+func MulChecked[T go_constraints.Integer](x, y T) optionals.Optional[T] {
+	if x == 0 || y == 0 {
+		return optionals.Some(T(0))
+	}
+
+	// In two's complement, -1 * min overflows, but min / -1 == min, so the
+	// division check below would miss it.
+	if isSigned[T]() && ((x == ^T(0) && y == minValue[T]()) || (y == ^T(0) && x == minValue[T]())) {
+		return optionals.None[T]()
+	}
+
+	rv := x * y
+	if rv/y != x {
+		return optionals.None[T]()
+	}
+	return optionals.Some(rv)
+}
+
+// Returns the least common multiple of a and b, or None if the result
+// overflows T.
+func LCMChecked[T go_constraints.Integer](a, b T) optionals.Optional[T] {
+	if a == 0 || b == 0 {
+		return optionals.Some(T(0))
+	}
+
+	gcd := GCD(a, b)
+	if gcd < 0 {
+		// The GCD itself overflowed, which only happens when a == b == min.
+		return optionals.None[T]()
+	}
+
+	rv, ok := MulChecked(a/gcd, b).Get()
+	if !ok {
+		return optionals.None[T]()
+	}
+	if rv < 0 {
+		if rv == minValue[T]() {
+			return optionals.None[T]()
+		}
+		rv = -rv
+	}
+	return optionals.Some(rv)
+}
+
+// Returns x + y, clamped to the range of T.
+func AddSaturating[T go_constraints.Integer](x, y T) T {
+	if rv, ok := AddChecked(x, y).Get(); ok {
+		return rv
+	}
+	if x > 0 {
+		return maxValue[T]()
+	}
+	return minValue[T]()
+}
+
+// Returns x - y, clamped to the range of T.
+func SubSaturating[T go_constraints.Integer](x, y T) T {
+	if rv, ok := SubChecked(x, y).Get(); ok {
+		return rv
+	}
+	if isSigned[T]() && y < 0 {
+		return maxValue[T]()
+	}
+	return minValue[T]()
+}
+
+// Returns x * y, clamped to the range of T.
+func MulSaturating[T go_constraints.Integer](x, y T) T {
+	if rv, ok := MulChecked(x, y).Get(); ok {
+		return rv
+	}
+	if (x < 0) == (y < 0) {
+		return maxValue[T]()
+	}
+	return minValue[T]()
+}
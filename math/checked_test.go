@@ -0,0 +1,156 @@
+package math
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+	go_constraints "golang.org/x/exp/constraints"
+)
+
+func TestMinMaxValue(t *testing.T) {
+	assert.Equal(t, int8(math.MinInt8), minValue[int8]())
+	assert.Equal(t, int8(math.MaxInt8), maxValue[int8]())
+	assert.Equal(t, int64(math.MinInt64), minValue[int64]())
+	assert.Equal(t, int64(math.MaxInt64), maxValue[int64]())
+	assert.Equal(t, uint16(0), minValue[uint16]())
+	assert.Equal(t, uint16(math.MaxUint16), maxValue[uint16]())
+	assert.Equal(t, uint64(math.MaxUint64), maxValue[uint64]())
+}
+
+func TestChecked(t *testing.T) {
+	assert.Equal(t, optionals.Some(int8(127)), AddChecked[int8](100, 27))
+	assert.Equal(t, optionals.None[int8](), AddChecked[int8](100, 28))
+	assert.Equal(t, optionals.None[uint8](), SubChecked[uint8](1, 2))
+	assert.Equal(t, optionals.None[int64](), MulChecked[int64](-1, math.MinInt64))
+	assert.Equal(t, optionals.None[int64](), MulChecked[int64](math.MinInt64, -1))
+	assert.Equal(t, optionals.Some(int64(math.MinInt64)), MulChecked[int64](math.MinInt64, 1))
+	assert.Equal(t, optionals.None[int32](), LCMChecked[int32](math.MaxInt32, math.MaxInt32-1))
+	assert.Equal(t, optionals.Some(int32(12)), LCMChecked[int32](-4, 6))
+
+	assert.Equal(t, int8(127), AddSaturating[int8](100, 100))
+	assert.Equal(t, int8(-128), AddSaturating[int8](-100, -100))
+	assert.Equal(t, int8(127), SubSaturating[int8](100, -100))
+	assert.Equal(t, uint8(0), SubSaturating[uint8](1, 2))
+	assert.Equal(t, int8(-128), MulSaturating[int8](-100, 2))
+	assert.Equal(t, uint8(255), MulSaturating[uint8](100, 100))
+}
+
+// Returns v as a T if it is in range, or None otherwise.
+func fromBig[T go_constraints.Integer](v *big.Int) optionals.Optional[T] {
+	lo, hi := toBig(minValue[T]()), toBig(maxValue[T]())
+	if v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		return optionals.None[T]()
+	}
+	if isSigned[T]() {
+		return optionals.Some(T(v.Int64()))
+	}
+	return optionals.Some(T(v.Uint64()))
+}
+
+func toBig[T go_constraints.Integer](x T) *big.Int {
+	if x < 0 {
+		return big.NewInt(int64(x))
+	}
+	return new(big.Int).SetUint64(uint64(x))
+}
+
+func bigLCM(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Int)
+	}
+	gcd := new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+	rv := new(big.Int).Mul(a, b)
+	rv.Abs(rv)
+	return rv.Quo(rv, gcd)
+}
+
+// Checks each operation on x and y against the same operation on big.Int.
+func checkAgainstBig[T go_constraints.Integer](t *testing.T, x, y T) {
+	bx, by := toBig(x), toBig(y)
+
+	expectedSum := fromBig[T](new(big.Int).Add(bx, by))
+	expectedDifference := fromBig[T](new(big.Int).Sub(bx, by))
+	expectedProduct := fromBig[T](new(big.Int).Mul(bx, by))
+	expectedLCM := fromBig[T](bigLCM(bx, by))
+
+	assert.Equal(t, expectedSum, AddChecked(x, y), "AddChecked(%d, %d)", x, y)
+	assert.Equal(t, expectedDifference, SubChecked(x, y), "SubChecked(%d, %d)", x, y)
+	assert.Equal(t, expectedProduct, MulChecked(x, y), "MulChecked(%d, %d)", x, y)
+	assert.Equal(t, expectedLCM, LCMChecked(x, y), "LCMChecked(%d, %d)", x, y)
+
+	// GCD is correct whenever the result is representable.
+	bigGCD := new(big.Int).GCD(nil, nil, new(big.Int).Abs(bx), new(big.Int).Abs(by))
+	if expected, ok := fromBig[T](bigGCD).Get(); ok {
+		assert.Equal(t, expected, GCD(x, y), "GCD(%d, %d)", x, y)
+	}
+	if expected, ok := expectedLCM.Get(); ok {
+		assert.Equal(t, expected, LCM(x, y), "LCM(%d, %d)", x, y)
+	}
+
+	// Saturating results are clamped versions of the exact results.
+	clamp := func(v *big.Int) T {
+		if v.Cmp(toBig(minValue[T]())) < 0 {
+			return minValue[T]()
+		}
+		if v.Cmp(toBig(maxValue[T]())) > 0 {
+			return maxValue[T]()
+		}
+		return fromBig[T](v).GetOrDefault(0)
+	}
+	assert.Equal(t, clamp(new(big.Int).Add(bx, by)), AddSaturating(x, y), "AddSaturating(%d, %d)", x, y)
+	assert.Equal(t, clamp(new(big.Int).Sub(bx, by)), SubSaturating(x, y), "SubSaturating(%d, %d)", x, y)
+	assert.Equal(t, clamp(new(big.Int).Mul(bx, by)), MulSaturating(x, y), "MulSaturating(%d, %d)", x, y)
+}
+
+func TestCheckedExhaustive8Bit(t *testing.T) {
+	for x := math.MinInt8; x <= math.MaxInt8; x++ {
+		for y := math.MinInt8; y <= math.MaxInt8; y++ {
+			checkAgainstBig(t, int8(x), int8(y))
+			checkAgainstBig(t, uint8(x), uint8(y))
+			if t.Failed() {
+				return
+			}
+		}
+	}
+}
+
+var fuzzSeeds = [][2]int64{
+	{0, 0},
+	{1, -1},
+	{math.MaxInt64, 1},
+	{math.MinInt64, -1},
+	{math.MinInt64, math.MinInt64},
+	{math.MaxInt64, math.MaxInt64 - 1},
+	{1 << 32, 1 << 31},
+	{-3037000500, 3037000500},
+}
+
+func FuzzCheckedInt64(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, x, y int64) {
+		checkAgainstBig(t, x, y)
+	})
+}
+
+func FuzzCheckedUint64(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(uint64(seed[0]), uint64(seed[1]))
+	}
+	f.Fuzz(func(t *testing.T, x, y uint64) {
+		checkAgainstBig(t, x, y)
+	})
+}
+
+func FuzzCheckedInt32(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(int32(seed[0]), int32(seed[1]))
+	}
+	f.Fuzz(func(t *testing.T, x, y int32) {
+		checkAgainstBig(t, x, y)
+	})
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The SI prefixes used for durations under a minute.
+var durationPrefixes = []prefix{
+	{"n", -9},
+	{"µ", -6},
+	{"m", -3},
+	{"", 0},
+}
+
+// Formats d to n significant figures, as in "1.23 ms" or "4.50 min". Durations
+// under a minute are given in seconds with an SI prefix; longer durations are
+// given in minutes or hours. Panics if n is not positive.
+func Duration(d time.Duration, n int) string {
+	// Pick each unit after rounding, as IEC picks prefixes, so that 59.96 s to
+	// three significant figures is "1.00 min" rather than "60.0 s". A value
+	// that rounds up to 60 is exactly 60 once rounded, so carrying it to the
+	// next unit doesn't round twice.
+	seconds := d.Seconds()
+	if math.Abs(seconds) < 60 {
+		rounded := roundedValue(seconds, n)
+		if math.Abs(rounded) < 60 {
+			return formatSI(seconds, n, "s", durationPrefixes)
+		}
+		seconds = rounded
+	}
+
+	minutes := seconds / 60
+	if math.Abs(minutes) < 60 {
+		rounded := roundedValue(minutes, n)
+		if math.Abs(rounded) < 60 {
+			return SigFigs(minutes, n) + " min"
+		}
+		minutes = rounded
+	}
+
+	return SigFigs(minutes/60, n) + " h"
+}
+
+// Parses a duration in the format produced by Duration. The micro prefix may be
+// written as "µ" or "u".
+func ParseDuration(s string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(s)
+
+	var value float64
+	var err error
+	switch {
+	case strings.HasSuffix(trimmed, "min"):
+		value, err = Parse(trimmed, "min")
+		value *= float64(time.Minute)
+	case strings.HasSuffix(trimmed, "h"):
+		value, err = Parse(trimmed, "h")
+		value *= float64(time.Hour)
+	default:
+		value, err = Parse(trimmed, "s")
+		value *= float64(time.Second)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid duration %q", s)
+	}
+
+	if math.IsNaN(value) || value >= math.MaxInt64 || value < math.MinInt64 {
+		return 0, errors.Errorf("duration %q out of range", s)
+	}
+	return time.Duration(math.Round(value)), nil
+}
@@ -0,0 +1,63 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	testCases := []struct {
+		d        time.Duration
+		n        int
+		expected string
+	}{
+		{1234567 * time.Nanosecond, 3, "1.23 ms"},
+		{999999 * time.Nanosecond, 3, "1.00 ms"},
+		{15 * time.Nanosecond, 3, "15.0 ns"},
+		{-2 * time.Microsecond, 2, "-2.0 µs"},
+		{0, 3, "0.00 s"},
+		{42 * time.Second, 3, "42.0 s"},
+		{270 * time.Second, 3, "4.50 min"},
+		{-90 * time.Minute, 2, "-1.5 h"},
+		{1000 * time.Hour, 2, "1000 h"},
+		{59960 * time.Millisecond, 3, "1.00 min"},
+		{-59960 * time.Millisecond, 3, "-1.00 min"},
+		{59940 * time.Millisecond, 3, "59.9 s"},
+		{55 * time.Second, 1, "1 min"},
+		{3599990 * time.Millisecond, 3, "1.00 h"},
+		{3597 * time.Second, 3, "1.00 h"},
+		{3590 * time.Second, 3, "59.8 min"},
+		{999999999 * time.Nanosecond, 3, "1.00 s"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, Duration(tc.d, tc.n), tc.d.String())
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected time.Duration
+	}{
+		{"1.23 ms", 1230 * time.Microsecond},
+		{"15 ns", 15 * time.Nanosecond},
+		{"2.0 us", 2 * time.Microsecond},
+		{"-2.0 µs", -2 * time.Microsecond},
+		{"42 s", 42 * time.Second},
+		{"4.50 min", 270 * time.Second},
+		{"1.5 h", 90 * time.Minute},
+	}
+	for _, tc := range testCases {
+		actual, err := ParseDuration(tc.s)
+		if assert.NoError(t, err, tc.s) {
+			assert.Equal(t, tc.expected, actual, tc.s)
+		}
+	}
+
+	for _, s := range []string{"", "1.5", "1.5 days", "Inf s", "NaN h", "1e300 h"} {
+		_, err := ParseDuration(s)
+		assert.Error(t, err, s)
+	}
+}
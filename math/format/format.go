@@ -0,0 +1,207 @@
+// Package format renders numbers to a given number of significant figures,
+// with SI or binary (IEC) prefixes and units, and parses them back.
+//
+// Unlike math.RoundToSigFigs, which returns a float, the output keeps trailing
+// significant zeros: 12000 to three significant figures is "12.0k", not "12k".
+//
+// Rounding matches math.RoundToSigFigs: it is decimal-exact, treating a number
+// as the shortest decimal that converts back to it, and ties are rounded away
+// from zero. So 0.0012345 to four significant figures is "0.001235", and 1.005
+// to three is "1.01", even though the nearest float64s are slightly less than
+// 0.0012345 and 1.005. NaN and infinities are rendered as "NaN", "Inf" and
+// "-Inf", followed by the unit if there is one.
+package format
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/akitasoftware/go-utils/internal/decimal"
+)
+
+type prefix struct {
+	symbol string
+
+	// The power of the base that this prefix represents.
+	exponent int
+}
+
+// SI prefixes for powers of 1000, from 10^-18 to 10^18.
+var siPrefixes = []prefix{
+	{"a", -18},
+	{"f", -15},
+	{"p", -12},
+	{"n", -9},
+	{"µ", -6},
+	{"m", -3},
+	{"", 0},
+	{"k", 3},
+	{"M", 6},
+	{"G", 9},
+	{"T", 12},
+	{"P", 15},
+	{"E", 18},
+}
+
+// IEC prefixes for powers of 1024, from 1024^0 to 1024^6.
+var iecPrefixes = []prefix{
+	{"", 0},
+	{"Ki", 10},
+	{"Mi", 20},
+	{"Gi", 30},
+	{"Ti", 40},
+	{"Pi", 50},
+	{"Ei", 60},
+}
+
+// Formats x to n significant figures, without a prefix. Large and small
+// numbers are written out in full, so 1234567 to three significant figures is
+// "1230000". Panics if n is not positive.
+func SigFigs(x float64, n int) string {
+	if s, ok := formatNonFinite(x, ""); ok {
+		return s
+	}
+	digits, exp := roundDigits(x, n)
+	return placeDecimalPoint(digits, exp+1)
+}
+
+// Formats x to n significant figures with an SI prefix, chosen so that the
+// number is at least 1 and less than 1000 where possible. The prefixed unit is
+// separated from the number by a space, as in "1.23 ms". If unit is empty, the
+// prefix is attached directly to the number, as in "12.0k", so that a unit can
+// be appended by the caller: "12.0k req/s". Panics if n is not positive.
+func SI(x float64, n int, unit string) string {
+	if s, ok := formatNonFinite(x, unit); ok {
+		return s
+	}
+	return formatSI(x, n, unit, siPrefixes)
+}
+
+func formatSI(x float64, n int, unit string, prefixes []prefix) string {
+	digits, exp := roundDigits(x, n)
+
+	// Pick the largest prefix not exceeding the rounded value, falling back to
+	// the smallest prefix for tiny values.
+	p := prefixes[0]
+	if x != 0 {
+		for _, candidate := range prefixes {
+			if candidate.exponent <= exp {
+				p = candidate
+			}
+		}
+	} else {
+		p = prefix{"", 0}
+	}
+
+	return withUnit(placeDecimalPoint(digits, exp-p.exponent+1), p.symbol, unit)
+}
+
+// Formats x to n significant figures with a binary prefix, chosen so that the
+// number is less than 1024 where possible, as in "4.50 KiB". If unit is empty,
+// the prefix is attached directly to the number. Panics if n is not positive.
+func IEC(x float64, n int, unit string) string {
+	if s, ok := formatNonFinite(x, unit); ok {
+		return s
+	}
+
+	i := 0
+	for i+1 < len(iecPrefixes) && math.Abs(x) >= math.Ldexp(1, iecPrefixes[i+1].exponent) {
+		i++
+	}
+
+	// Dividing by a power of two is exact, so the scaled value can be rounded
+	// directly. Rounding may carry the value up to the next prefix.
+	for {
+		scaled := math.Ldexp(x, -iecPrefixes[i].exponent)
+		digits, exp := roundDigits(scaled, n)
+		if i+1 < len(iecPrefixes) && exp >= 3 {
+			rounded := parseDigits(digits, exp, n)
+			if math.Abs(rounded) >= 1024 {
+				// Carry the rounded value, so that 1023.9 becomes 1.000Ki
+				// rather than 0.9999Ki.
+				x = math.Ldexp(rounded, iecPrefixes[i].exponent)
+				i++
+				continue
+			}
+		}
+		return withUnit(placeDecimalPoint(digits, exp+1), iecPrefixes[i].symbol, unit)
+	}
+}
+
+func formatNonFinite(x float64, unit string) (string, bool) {
+	var s string
+	switch {
+	case math.IsNaN(x):
+		s = "NaN"
+	case math.IsInf(x, 1):
+		s = "Inf"
+	case math.IsInf(x, -1):
+		s = "-Inf"
+	default:
+		return "", false
+	}
+	return withUnit(s, "", unit), true
+}
+
+func withUnit(number, prefix, unit string) string {
+	if unit == "" {
+		return number + prefix
+	}
+	return number + " " + prefix + unit
+}
+
+// Rounds x to n significant figures, returning the digits, including a leading
+// minus sign if x is negative, and the decimal exponent of the first digit.
+// For example, -1234.5 to three significant figures is ("-123", 3).
+func roundDigits(x float64, n int) (string, int) {
+	if n <= 0 {
+		panic("format: number of significant figures must be positive")
+	}
+
+	// Avoid rendering negative zero as "-0".
+	if x == 0 {
+		return strings.Repeat("0", n), 0
+	}
+
+	// Round the same way as math.RoundToSigFigs. The digits have no trailing
+	// zeros, so pad them to n significant figures.
+	var buf [32]byte
+	digits, exp := decimal.Round(buf[:0], x, 64, decimal.HalfAwayFromZero, func(int) int { return n })
+	rv := string(digits) + strings.Repeat("0", n-len(digits))
+	if x < 0 {
+		rv = "-" + rv
+	}
+	return rv, exp
+}
+
+// Returns x rounded to n significant figures.
+func roundedValue(x float64, n int) float64 {
+	digits, exp := roundDigits(x, n)
+	return parseDigits(digits, exp, n)
+}
+
+// Returns the value of n digits returned by roundDigits with the given
+// exponent.
+func parseDigits(digits string, exp, n int) float64 {
+	rv, _ := strconv.ParseFloat(digits+"e"+strconv.Itoa(exp-n+1), 64)
+	return rv
+}
+
+// Places a decimal point after the first pointPos digits, padding with zeros
+// as needed.
+func placeDecimalPoint(digits string, pointPos int) string {
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	switch {
+	case pointPos >= len(digits):
+		return sign + digits + strings.Repeat("0", pointPos-len(digits))
+	case pointPos <= 0:
+		return sign + "0." + strings.Repeat("0", -pointPos) + digits
+	default:
+		return sign + digits[:pointPos] + "." + digits[pointPos:]
+	}
+}
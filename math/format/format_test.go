@@ -0,0 +1,168 @@
+package format
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	gomath "github.com/akitasoftware/go-utils/math"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigFigs(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		n        int
+		expected string
+	}{
+		{0, 3, "0.00"},
+		{math.Copysign(0, -1), 2, "0.0"},
+		{1, 3, "1.00"},
+		{12000, 3, "12000"},
+		{1234567, 3, "1230000"},
+		{0.000123456, 2, "0.00012"},
+		{-9.996, 3, "-10.0"},
+		{0.1 + 0.2, 3, "0.300"},
+		{2.5, 1, "3"},
+		{3.5, 1, "4"},
+		{-2.5, 1, "-3"},
+		{1.005, 3, "1.01"},
+		{0.0012345, 4, "0.001235"},
+		{0.1, 20, "0.10000000000000000000"},
+		{math.MaxFloat64, 3, "180" + strings.Repeat("0", 306)},
+		{math.NaN(), 3, "NaN"},
+		{math.Inf(1), 3, "Inf"},
+		{math.Inf(-1), 3, "-Inf"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, SigFigs(tc.x, tc.n), "SigFigs(%v, %d)", tc.x, tc.n)
+	}
+	assert.Panics(t, func() { SigFigs(1, 0) })
+}
+
+func TestSI(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		n        int
+		unit     string
+		expected string
+	}{
+		{0.00123, 3, "s", "1.23 ms"},
+		{0.0012345, 4, "s", "1.235 ms"},
+		{12000, 3, "", "12.0k"},
+		{999.6, 3, "", "1.00k"},
+		{999.4, 3, "", "999"},
+		{-1500000, 2, "B", "-1.5 MB"},
+		{0, 3, "s", "0.00 s"},
+		{42, 2, "s", "42 s"},
+		{1.5e-7, 2, "s", "150 ns"},
+		{1e21, 2, "B", "1000 EB"},
+		{1.23e-20, 3, "s", "0.0123 as"},
+		{math.NaN(), 3, "s", "NaN s"},
+		{math.Inf(-1), 3, "", "-Inf"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, SI(tc.x, tc.n, tc.unit), "SI(%v, %d, %q)", tc.x, tc.n, tc.unit)
+	}
+}
+
+func TestIEC(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		n        int
+		unit     string
+		expected string
+	}{
+		{4608, 2, "B", "4.5 KiB"},
+		{4608, 3, "B", "4.50 KiB"},
+		{1000, 3, "B", "1000 B"},
+		{1023.9, 3, "B", "1020 B"},
+		{1023.9, 4, "B", "1.000 KiB"},
+		{1 << 30, 3, "B", "1.00 GiB"},
+		{-3 << 20, 2, "", "-3.0Mi"},
+		{0.5, 2, "B", "0.50 B"},
+		{math.Inf(1), 3, "B", "Inf B"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, IEC(tc.x, tc.n, tc.unit), "IEC(%v, %d, %q)", tc.x, tc.n, tc.unit)
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		s        string
+		unit     string
+		expected float64
+	}{
+		{"1.23 ms", "s", 0.00123},
+		{"1.23 us", "s", 0.00000123},
+		{"1.23 µs", "s", 0.00000123},
+		{"12.0k req/s", "req/s", 12000},
+		{"12.0 kreq/s", "req/s", 12000},
+		{"4.5 KiB", "B", 4608},
+		{"-1.5 MB", "B", -1500000},
+		{"12000", "", 12000},
+		{"12.0k", "", 12000},
+		{"5m", "", 0.005},
+		{"1.5Ki", "", 1536},
+		{"  42 s ", "s", 42},
+		{"-Inf", "", math.Inf(-1)},
+		{"Inf s", "s", math.Inf(1)},
+	}
+	for _, tc := range testCases {
+		actual, err := Parse(tc.s, tc.unit)
+		if assert.NoError(t, err, tc.s) {
+			assert.Equal(t, tc.expected, actual, tc.s)
+		}
+	}
+
+	actual, err := Parse("NaN ms", "ms")
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(actual))
+
+	for _, s := range []string{"", "ms", "1.2.3 s", "1 xs", "12 B"} {
+		_, err := Parse(s, "s")
+		assert.Error(t, err, s)
+	}
+
+	// Without a unit, anything after the number must be an attached prefix.
+	for _, s := range []string{"5 m", "1.5 Ki", "12 B", "12.0 k", "12 s"} {
+		_, err := Parse(s, "")
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, x := range []float64{0, 1, -1, 0.00123, 12345, 6.02e23, -4.2e-9, 999.999} {
+		for n := 1; n <= 6; n++ {
+			for _, format := range []func(float64, int, string) string{SI, IEC} {
+				s := format(x, n, "B")
+				parsed, err := Parse(s, "B")
+				if assert.NoError(t, err, s) {
+					// Parsing gives back the rounded number, which formats the
+					// same way.
+					assert.Equal(t, s, format(parsed, n, "B"))
+					assert.InEpsilon(t, x+1e-300, parsed+1e-300, math.Pow10(1-n), s)
+				}
+			}
+		}
+	}
+}
+
+// Formatting rounds the same way as math.RoundToSigFigs.
+func TestRoundingMatchesMath(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		// Short decimals, which are most likely to be ties.
+		x := float64(rng.Intn(200000)-100000) * math.Pow10(rng.Intn(20)-10)
+		n := rng.Intn(6) + 1
+
+		formatted, err := strconv.ParseFloat(SigFigs(x, n), 64)
+		if assert.NoError(t, err) {
+			assert.Equal(t, gomath.RoundToSigFigs(x, n), formatted, "%v to %d significant figures", x, n)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package format
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Parses a number in the format produced by SigFigs, SI or IEC, with the given
+// unit. The prefix, if any, may be attached to either the number or the unit,
+// and the space before the unit is optional; so "12.0k req/s", "12.0 kreq/s"
+// and "12.0kreq/s" all parse as 12000 with unit "req/s". The micro prefix may
+// be written as "µ" or "u". Also accepts NaN and infinities.
+//
+// If unit is empty, any prefix must be attached to the number, as SI and IEC
+// attach it, and nothing may follow it. So "12.0k" parses as 12000, but "5 m"
+// is rejected rather than mistaking a unit of metres for the milli prefix.
+//
+// Decimal prefixes are applied exactly, so Parse("1.23 ms", "s") returns the
+// float64 closest to 0.00123.
+func Parse(s string, unit string) (float64, error) {
+	number := strings.TrimSpace(s)
+	if !strings.HasSuffix(number, unit) {
+		return 0, errors.Errorf("%q does not have unit %q", s, unit)
+	}
+	number = strings.TrimSpace(strings.TrimSuffix(number, unit))
+
+	// Try the number without a prefix first, so that "Inf" isn't mistaken for
+	// "In" with the femto prefix.
+	if v, err := strconv.ParseFloat(number, 64); err == nil {
+		return v, nil
+	}
+
+	for _, p := range iecPrefixes[1:] {
+		if mantissa, ok := trimPrefixSymbol(number, p.symbol, unit == ""); ok {
+			v, err := strconv.ParseFloat(mantissa, 64)
+			if err != nil {
+				return 0, errors.Errorf("invalid number %q", s)
+			}
+			return math.Ldexp(v, p.exponent), nil
+		}
+	}
+
+	for _, p := range siPrefixes {
+		if p.symbol == "" {
+			continue
+		}
+
+		symbols := []string{p.symbol}
+		if p.symbol == "µ" {
+			symbols = append(symbols, "u")
+		}
+		for _, symbol := range symbols {
+			if mantissa, ok := trimPrefixSymbol(number, symbol, unit == ""); ok {
+				// Apply the prefix as a decimal exponent, so that rounding only
+				// happens once.
+				v, err := strconv.ParseFloat(mantissa+"e"+strconv.Itoa(p.exponent), 64)
+				if err != nil {
+					return 0, errors.Errorf("invalid number %q", s)
+				}
+				return v, nil
+			}
+		}
+	}
+
+	return 0, errors.Errorf("invalid number %q", s)
+}
+
+// Removes the given prefix symbol from the end of s, along with any whitespace
+// before it unless the symbol must be attached to the number.
+func trimPrefixSymbol(s, symbol string, attached bool) (string, bool) {
+	if !strings.HasSuffix(s, symbol) {
+		return "", false
+	}
+	rv := strings.TrimSuffix(s, symbol)
+	if attached {
+		return rv, strings.TrimSpace(rv) == rv
+	}
+	return strings.TrimSpace(rv), true
+}
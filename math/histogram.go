@@ -0,0 +1,233 @@
+package math
+
+import (
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/akitasoftware/go-utils/constraints"
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+// Counts values in buckets determined by a BucketScheme. Positive values and
+// the magnitudes of negative values are bucketed separately, and zeros are
+// counted on their own, as in OpenTelemetry's exponential histogram data model.
+// A Summary of the values is also maintained.
+type Histogram[T constraints.Number] struct {
+	scheme BucketScheme
+
+	// Maps bucket indices to counts.
+	positive map[int]uint64
+	negative map[int]uint64
+
+	zeroCount uint64
+	summary   Summary[T]
+}
+
+// Returns an empty histogram using the given bucket scheme. Panics if the
+// scheme is nil or its parameters are invalid, such as a LinearBuckets whose
+// Width isn't positive.
+func NewHistogram[T constraints.Number](scheme BucketScheme) *Histogram[T] {
+	validateBucketScheme(scheme)
+	return &Histogram[T]{
+		scheme:   scheme,
+		positive: map[int]uint64{},
+		negative: map[int]uint64{},
+	}
+}
+
+func (h *Histogram[T]) Scheme() BucketScheme {
+	return h.scheme
+}
+
+// Adds a value to the histogram. NaN and infinite values are ignored.
+func (h *Histogram[T]) Add(x T) {
+	v := float64(x)
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return
+	case v > 0:
+		h.positive[h.scheme.Index(v)]++
+	case v < 0:
+		h.negative[h.scheme.Index(-v)]++
+	default:
+		h.zeroCount++
+	}
+	h.summary.Add(x)
+}
+
+// Adds the values counted by other to this histogram. Both histograms must use
+// the same bucket scheme.
+func (h *Histogram[T]) Merge(other *Histogram[T]) error {
+	if !reflect.DeepEqual(h.scheme, other.scheme) {
+		return errors.Errorf("cannot merge histogram with bucket scheme %#v into one with bucket scheme %#v", other.scheme, h.scheme)
+	}
+
+	for index, count := range other.positive {
+		h.positive[index] += count
+	}
+	for index, count := range other.negative {
+		h.negative[index] += count
+	}
+	h.zeroCount += other.zeroCount
+	h.summary.Merge(&other.summary)
+	return nil
+}
+
+func (h *Histogram[T]) Count() int64 {
+	return h.summary.Count()
+}
+
+func (h *Histogram[T]) Sum() T {
+	return h.summary.Sum()
+}
+
+// Returns None if the histogram is empty.
+func (h *Histogram[T]) Min() optionals.Optional[T] {
+	return h.summary.Min()
+}
+
+// Returns None if the histogram is empty.
+func (h *Histogram[T]) Max() optionals.Optional[T] {
+	return h.summary.Max()
+}
+
+// Returns a summary of the values added to the histogram.
+func (h *Histogram[T]) Summary() Summary[T] {
+	return h.summary
+}
+
+// A bucket in a Histogram, covering values in (Lower, Upper]. The bucket for
+// zeros has Lower and Upper both equal to zero.
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count uint64
+}
+
+// Returns the non-empty buckets in ascending order of value. Buckets for
+// negative values have negated bounds, so they cover [Lower, Upper).
+func (h *Histogram[T]) Buckets() []HistogramBucket {
+	rv := make([]HistogramBucket, 0, len(h.negative)+len(h.positive)+1)
+
+	negativeIndices := sortedIndices(h.negative)
+	for i := len(negativeIndices) - 1; i >= 0; i-- {
+		index := negativeIndices[i]
+		lower, upper := h.scheme.Bounds(index)
+		rv = append(rv, HistogramBucket{Lower: -upper, Upper: -lower, Count: h.negative[index]})
+	}
+
+	if h.zeroCount > 0 {
+		rv = append(rv, HistogramBucket{Count: h.zeroCount})
+	}
+
+	for _, index := range sortedIndices(h.positive) {
+		lower, upper := h.scheme.Bounds(index)
+		rv = append(rv, HistogramBucket{Lower: lower, Upper: upper, Count: h.positive[index]})
+	}
+
+	return rv
+}
+
+// Estimates the q-quantile of the values added to the histogram by linear
+// interpolation within the bucket containing it. Estimates are clamped to the
+// observed minimum and maximum. Returns None if the histogram is empty or q is
+// not in [0, 1].
+func (h *Histogram[T]) Quantile(q float64) optionals.Optional[float64] {
+	if h.Count() == 0 || !(q >= 0 && q <= 1) {
+		return optionals.None[float64]()
+	}
+
+	min := float64(h.summary.min)
+	max := float64(h.summary.max)
+	clamp := func(x float64) float64 {
+		return Max(min, Min(max, x))
+	}
+
+	rank := q * float64(h.Count())
+	var seen uint64
+	for _, bucket := range h.Buckets() {
+		if float64(seen+bucket.Count) >= rank {
+			fraction := (rank - float64(seen)) / float64(bucket.Count)
+			lower, upper := clamp(bucket.Lower), clamp(bucket.Upper)
+			return optionals.Some(lower + fraction*(upper-lower))
+		}
+		seen += bucket.Count
+	}
+	return optionals.Some(max)
+}
+
+// Returns the estimated value at percentile p, where p is in [0, 100].
+func (h *Histogram[T]) Percentile(p float64) optionals.Optional[float64] {
+	return h.Quantile(p / 100)
+}
+
+// A contiguous run of buckets in an ExponentialHistogramDataPoint.
+type ExponentialHistogramBuckets struct {
+	// The index of the first bucket.
+	Offset int32
+
+	// Counts for consecutive buckets, starting at Offset.
+	BucketCounts []uint64
+}
+
+// Mirrors the ExponentialHistogramDataPoint message in OpenTelemetry's metrics
+// data model, for export.
+type ExponentialHistogramDataPoint struct {
+	Count     uint64
+	Sum       float64
+	Min       optionals.Optional[float64]
+	Max       optionals.Optional[float64]
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialHistogramBuckets
+	Negative  ExponentialHistogramBuckets
+}
+
+// Converts the histogram to OpenTelemetry's exponential histogram data model.
+// Returns an error unless the histogram uses ExponentialBuckets.
+func (h *Histogram[T]) ToExponentialHistogramDataPoint() (ExponentialHistogramDataPoint, error) {
+	scheme, ok := h.scheme.(ExponentialBuckets)
+	if !ok {
+		return ExponentialHistogramDataPoint{}, errors.Errorf("histogram with bucket scheme %#v cannot be represented as an exponential histogram", h.scheme)
+	}
+
+	toFloat := func(x T) float64 { return float64(x) }
+	return ExponentialHistogramDataPoint{
+		Count:     uint64(h.Count()),
+		Sum:       float64(h.Sum()),
+		Min:       optionals.Map(h.Min(), toFloat),
+		Max:       optionals.Map(h.Max(), toFloat),
+		Scale:     int32(scheme.Scale),
+		ZeroCount: h.zeroCount,
+		Positive:  toDenseBuckets(h.positive),
+		Negative:  toDenseBuckets(h.negative),
+	}, nil
+}
+
+func toDenseBuckets(counts map[int]uint64) ExponentialHistogramBuckets {
+	indices := sortedIndices(counts)
+	if len(indices) == 0 {
+		return ExponentialHistogramBuckets{}
+	}
+
+	offset := indices[0]
+	dense := make([]uint64, indices[len(indices)-1]-offset+1)
+	for _, index := range indices {
+		dense[index-offset] = counts[index]
+	}
+	return ExponentialHistogramBuckets{
+		Offset:       int32(offset),
+		BucketCounts: dense,
+	}
+}
+
+func sortedIndices(counts map[int]uint64) []int {
+	rv := make([]int, 0, len(counts))
+	for index := range counts {
+		rv = append(rv, index)
+	}
+	sort.Ints(rv)
+	return rv
+}
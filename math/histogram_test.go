@@ -0,0 +1,257 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketSchemes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		scheme   BucketScheme
+		x        float64
+		expected int
+	}{
+		{"linear", LinearBuckets{Width: 10}, 0.5, 0},
+		{"linear upper bound", LinearBuckets{Width: 10}, 10, 0},
+		{"linear above bound", LinearBuckets{Width: 10}, 10.5, 1},
+		{"exponential", ExponentialBuckets{Scale: 0}, 3, 1},
+		{"exponential power of two", ExponentialBuckets{Scale: 0}, 4, 1},
+		{"exponential fine", ExponentialBuckets{Scale: 3}, 1.1, 1},
+		{"exponential coarse", ExponentialBuckets{Scale: -1}, 5, 1},
+		{"exponential small", ExponentialBuckets{Scale: 0}, 0.3, -2},
+		{"sigfig", SigFigBuckets{SigFigs: 2}, 1.05, 0},
+		{"sigfig upper bound", SigFigBuckets{SigFigs: 2}, 1.1, 0},
+		{"sigfig next decade", SigFigBuckets{SigFigs: 2}, 10.5, 90},
+		{"sigfig power of ten", SigFigBuckets{SigFigs: 2}, 10, 89},
+		{"sigfig small", SigFigBuckets{SigFigs: 2}, 0.95, -6},
+		{"explicit", NewExplicitBuckets(1, 5, 10), 1, 0},
+		{"explicit middle", NewExplicitBuckets(1, 5, 10), 7, 2},
+		{"explicit overflow", NewExplicitBuckets(1, 5, 10), 11, 3},
+	}
+
+	for _, tc := range testCases {
+		index := tc.scheme.Index(tc.x)
+		assert.Equal(t, tc.expected, index, tc.name)
+
+		lower, upper := tc.scheme.Bounds(index)
+		assert.True(t, lower < tc.x && tc.x <= upper, fmt.Sprintf("%s: %v not in (%v, %v]", tc.name, tc.x, lower, upper))
+	}
+}
+
+func TestBucketSchemesPartition(t *testing.T) {
+	// Adjacent buckets must share boundaries exactly.
+	for _, scheme := range []BucketScheme{
+		LinearBuckets{Width: 0.1},
+		ExponentialBuckets{Scale: 4},
+		SigFigBuckets{SigFigs: 3},
+	} {
+		for index := -2000; index < 2000; index++ {
+			_, upper := scheme.Bounds(index)
+			nextLower, _ := scheme.Bounds(index + 1)
+			if !assert.Equal(t, upper, nextLower, fmt.Sprintf("%#v: bucket %d", scheme, index)) {
+				break
+			}
+		}
+	}
+}
+
+func TestInvalidBucketSchemes(t *testing.T) {
+	invalid := []BucketScheme{
+		nil,
+		LinearBuckets{},
+		LinearBuckets{Width: -1},
+		LinearBuckets{Width: math.Inf(1)},
+		LinearBuckets{Width: math.NaN()},
+		SigFigBuckets{},
+		SigFigBuckets{SigFigs: -1},
+		SigFigBuckets{SigFigs: 7},
+		ExponentialBuckets{Scale: -11},
+		ExponentialBuckets{Scale: 21},
+	}
+	for _, scheme := range invalid {
+		assert.Panics(t, func() { NewHistogram[int](scheme) }, "%#v", scheme)
+	}
+
+	valid := []BucketScheme{
+		LinearBuckets{Width: 0.5},
+		ExponentialBuckets{},
+		SigFigBuckets{SigFigs: 1},
+		NewExplicitBuckets(),
+		ExponentialBuckets{Scale: -10},
+		ExponentialBuckets{Scale: 20},
+		SigFigBuckets{SigFigs: 6},
+	}
+	for _, scheme := range valid {
+		h := NewHistogram[int](scheme)
+		h.Add(5)
+		assert.Equal(t, 1, len(h.Buckets()), "%#v", scheme)
+	}
+}
+
+func TestInvalidExplicitBuckets(t *testing.T) {
+	for _, bounds := range [][]float64{
+		{5, 1, 10},
+		{1, 1},
+		{0, 1},
+		{-1},
+		{1, math.Inf(1)},
+		{math.NaN()},
+	} {
+		assert.Panics(t, func() { NewExplicitBuckets(bounds...) }, "%v", bounds)
+	}
+}
+
+func TestBucketSchemesExtremeValues(t *testing.T) {
+	schemes := []BucketScheme{
+		LinearBuckets{Width: 1},
+		LinearBuckets{Width: 1e-300},
+		LinearBuckets{Width: 1e300},
+		ExponentialBuckets{Scale: -10},
+		ExponentialBuckets{Scale: 20},
+		SigFigBuckets{SigFigs: 1},
+		SigFigBuckets{SigFigs: 3},
+		SigFigBuckets{SigFigs: 6},
+	}
+	values := []float64{
+		5e-324,
+		1e-310,
+		math.SmallestNonzeroFloat64 * 3,
+		2.2250738585072014e-308,
+		1e10,
+		1e19,
+		math.MaxInt64,
+		math.MaxFloat64,
+	}
+
+	for _, scheme := range schemes {
+		for _, x := range values {
+			name := fmt.Sprintf("%#v with %v", scheme, x)
+			index := scheme.Index(x)
+			assert.GreaterOrEqual(t, index, minBucketIndex, name)
+			assert.LessOrEqual(t, index, maxBucketIndex, name)
+
+			// Values within the range of indices are in the right bucket. The
+			// lower bound is inclusive here because, among the smallest
+			// subnormals, the bounds of narrow buckets round to the same
+			// float64.
+			if index > minBucketIndex && index < maxBucketIndex {
+				lower, upper := scheme.Bounds(index)
+				assert.True(t, lower <= x && x <= upper, "%s: (%v, %v]", name, lower, upper)
+			}
+
+			h := NewHistogram[float64](scheme)
+			h.Add(x)
+			assert.Equal(t, int64(1), h.Count(), name)
+		}
+	}
+
+	// SigFig and exponential buckets index every positive float64 exactly.
+	for _, scheme := range []BucketScheme{SigFigBuckets{SigFigs: 6}, ExponentialBuckets{Scale: 20}} {
+		for _, x := range []float64{5e-324, math.MaxFloat64} {
+			index := scheme.Index(x)
+			lower, upper := scheme.Bounds(index)
+			assert.True(t, lower <= x && x <= upper, "%#v with %v: (%v, %v]", scheme, x, lower, upper)
+		}
+	}
+}
+
+func TestHistogramQuantiles(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]float64, 20000)
+	for i := range values {
+		values[i] = rng.ExpFloat64() * 100
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	for _, scheme := range []BucketScheme{
+		ExponentialBuckets{Scale: 5},
+		SigFigBuckets{SigFigs: 2},
+	} {
+		h := NewHistogram[float64](scheme)
+		for _, v := range values {
+			h.Add(v)
+		}
+
+		assert.Equal(t, int64(len(values)), h.Count())
+		assert.Equal(t, optionals.Some(sorted[0]), h.Quantile(0))
+		assert.Equal(t, optionals.Some(sorted[len(sorted)-1]), h.Quantile(1))
+
+		// Both schemes have a relative bucket width of a few percent.
+		for _, p := range []float64{10, 50, 90, 99} {
+			expected := sorted[int(p/100*float64(len(sorted)-1))]
+			actual := h.Percentile(p).GetOrDefault(0)
+			assert.InEpsilon(t, expected, actual, 0.05, fmt.Sprintf("%#v: p%v", scheme, p))
+		}
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	h := NewHistogram[int](LinearBuckets{Width: 10})
+	for _, v := range []int{-15, -5, 0, 0, 5, 10, 11, 25} {
+		h.Add(v)
+	}
+
+	expected := []HistogramBucket{
+		{Lower: -20, Upper: -10, Count: 1},
+		{Lower: -10, Upper: 0, Count: 1},
+		{Lower: 0, Upper: 0, Count: 2},
+		{Lower: 0, Upper: 10, Count: 2},
+		{Lower: 10, Upper: 20, Count: 1},
+		{Lower: 20, Upper: 30, Count: 1},
+	}
+	assert.Equal(t, expected, h.Buckets())
+	assert.Equal(t, optionals.Some(-15), h.Min())
+	assert.Equal(t, 31, h.Sum())
+}
+
+func TestHistogramMerge(t *testing.T) {
+	h1 := NewHistogram[float64](NewExplicitBuckets(1, 10, 100))
+	h2 := NewHistogram[float64](NewExplicitBuckets(1, 10, 100))
+	h1.Add(0.5)
+	h1.Add(50)
+	h2.Add(500)
+	h2.Add(5)
+
+	assert.NoError(t, h1.Merge(h2))
+	assert.Equal(t, int64(4), h1.Count())
+	assert.Equal(t, []HistogramBucket{
+		{Lower: 0, Upper: 1, Count: 1},
+		{Lower: 1, Upper: 10, Count: 1},
+		{Lower: 10, Upper: 100, Count: 1},
+		{Lower: 100, Upper: math.Inf(1), Count: 1},
+	}, h1.Buckets())
+	assert.Equal(t, optionals.Some(500.0), h1.Quantile(1))
+
+	assert.Error(t, h1.Merge(NewHistogram[float64](NewExplicitBuckets(1, 10))))
+}
+
+func TestHistogramToExponentialHistogramDataPoint(t *testing.T) {
+	h := NewHistogram[float64](ExponentialBuckets{Scale: 0})
+	for _, v := range []float64{-3, 0, 1.5, 3, 3.5, 10} {
+		h.Add(v)
+	}
+
+	dp, err := h.ToExponentialHistogramDataPoint()
+	assert.NoError(t, err)
+	assert.Equal(t, ExponentialHistogramDataPoint{
+		Count:     6,
+		Sum:       15,
+		Min:       optionals.Some(-3.0),
+		Max:       optionals.Some(10.0),
+		Scale:     0,
+		ZeroCount: 1,
+		Positive:  ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1, 2, 0, 1}},
+		Negative:  ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{1}},
+	}, dp)
+
+	_, err = NewHistogram[float64](LinearBuckets{Width: 1}).ToExponentialHistogramDataPoint()
+	assert.Error(t, err)
+}
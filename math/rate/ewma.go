@@ -0,0 +1,83 @@
+package rate
+
+import (
+	"math"
+	"time"
+)
+
+// The tick interval used by NewEWMA1, NewEWMA5 and NewEWMA15.
+const DefaultTickInterval = 5 * time.Second
+
+// An exponentially weighted moving average of an event rate, in the style of
+// the Unix load average. Events are recorded with Update, and the average is
+// folded forward once per tick interval by calling Tick. The weight of each
+// tick decays with time constant equal to the averaging window.
+//
+// Not safe for concurrent use; see Meter for a thread-safe wrapper that ticks
+// automatically.
+type EWMA struct {
+	// The weight given to each new tick.
+	alpha float64
+
+	// The tick interval, in seconds.
+	interval float64
+
+	// The events recorded since the last tick.
+	uncounted int64
+
+	// The average rate, in events per second.
+	rate float64
+
+	// False until the first tick, which initializes rate without smoothing.
+	initialized bool
+}
+
+// Returns an EWMA averaging over the given window, which expects Tick to be
+// called once per interval. Panics if either duration is not positive.
+func NewEWMA(window, interval time.Duration) *EWMA {
+	if window <= 0 || interval <= 0 {
+		panic("rate: EWMA window and interval must be positive")
+	}
+	return &EWMA{
+		alpha:    1 - math.Exp(-interval.Seconds()/window.Seconds()),
+		interval: interval.Seconds(),
+	}
+}
+
+// Returns a one-minute EWMA, ticking every DefaultTickInterval.
+func NewEWMA1() *EWMA {
+	return NewEWMA(time.Minute, DefaultTickInterval)
+}
+
+// Returns a five-minute EWMA, ticking every DefaultTickInterval.
+func NewEWMA5() *EWMA {
+	return NewEWMA(5*time.Minute, DefaultTickInterval)
+}
+
+// Returns a fifteen-minute EWMA, ticking every DefaultTickInterval.
+func NewEWMA15() *EWMA {
+	return NewEWMA(15*time.Minute, DefaultTickInterval)
+}
+
+// Records n events.
+func (e *EWMA) Update(n int64) {
+	e.uncounted += n
+}
+
+// Folds the events recorded since the last tick into the average.
+func (e *EWMA) Tick() {
+	instantRate := float64(e.uncounted) / e.interval
+	e.uncounted = 0
+
+	if e.initialized {
+		e.rate += e.alpha * (instantRate - e.rate)
+	} else {
+		e.rate = instantRate
+		e.initialized = true
+	}
+}
+
+// Returns the average rate in events per second, as of the last tick.
+func (e *EWMA) Rate() float64 {
+	return e.rate
+}
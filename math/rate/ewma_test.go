@@ -0,0 +1,59 @@
+package rate
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEWMA(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ewma     *EWMA
+		expected []float64 // Expected rate at the end of each minute.
+	}{
+		{
+			name:     "1m",
+			ewma:     NewEWMA1(),
+			expected: []float64{0.22072766, 0.08120117, 0.02987224},
+		},
+		{
+			name:     "5m",
+			ewma:     NewEWMA5(),
+			expected: []float64{0.49123845, 0.40219203, 0.32928698},
+		},
+		{
+			name:     "15m",
+			ewma:     NewEWMA15(),
+			expected: []float64{0.56130419, 0.52510399, 0.49123845},
+		},
+	}
+
+	for _, tc := range testCases {
+		// Three events in the first tick interval, and none afterwards.
+		tc.ewma.Update(3)
+		tc.ewma.Tick()
+		assert.InDelta(t, 0.6, tc.ewma.Rate(), 1e-8, tc.name)
+
+		for minute, expected := range tc.expected {
+			for i := 0; i < int(time.Minute/DefaultTickInterval); i++ {
+				tc.ewma.Tick()
+			}
+			assert.InDelta(t, expected, tc.ewma.Rate(), 1e-8, "%s after %d minutes", tc.name, minute+1)
+		}
+	}
+}
+
+func TestEWMAConverges(t *testing.T) {
+	e := NewEWMA(time.Minute, time.Second)
+	e.Update(100)
+	e.Tick()
+	for i := 0; i < 1200; i++ {
+		e.Update(10)
+		e.Tick()
+	}
+	assert.InDelta(t, 10, e.Rate(), 1e-6)
+	assert.False(t, math.IsNaN(e.Rate()))
+}
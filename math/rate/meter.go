@@ -0,0 +1,127 @@
+package rate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+)
+
+// Measures the rate of a stream of events, reporting the overall mean rate,
+// one-, five- and fifteen-minute exponentially weighted moving averages, and
+// the rate over a sliding window. Safe for concurrent use.
+type Meter struct {
+	mu sync.Mutex
+
+	clock    clock.Clock
+	start    time.Time
+	lastTick time.Time
+	count    int64
+
+	m1, m5, m15 *EWMA
+	window      *SlidingWindowCounter
+}
+
+// Returns a new meter whose sliding window covers the given duration, divided
+// into the given number of buckets. If clk is nil, the system clock is used.
+// Panics under the same conditions as NewSlidingWindowCounter.
+func NewMeter(window time.Duration, buckets int, clk clock.Clock) *Meter {
+	clk = clock.OrReal(clk)
+	now := clk.Now()
+	return &Meter{
+		clock:    clk,
+		start:    now,
+		lastTick: now,
+		m1:       NewEWMA1(),
+		m5:       NewEWMA5(),
+		m15:      NewEWMA15(),
+		window:   NewSlidingWindowCounter(window, buckets, clk),
+	}
+}
+
+// Records n events at the current time.
+func (m *Meter) Mark(n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tickIfNecessary()
+	m.count += n
+	m.m1.Update(n)
+	m.m5.Update(n)
+	m.m15.Update(n)
+	m.window.Add(n)
+}
+
+// Folds pending events into the moving averages once for each tick interval
+// that has elapsed.
+func (m *Meter) tickIfNecessary() {
+	elapsed := m.clock.Now().Sub(m.lastTick)
+	if elapsed < DefaultTickInterval {
+		return
+	}
+
+	ticks := int64(elapsed / DefaultTickInterval)
+	m.lastTick = m.lastTick.Add(time.Duration(ticks) * DefaultTickInterval)
+	for i := int64(0); i < ticks; i++ {
+		m.m1.Tick()
+		m.m5.Tick()
+		m.m15.Tick()
+	}
+}
+
+// Returns the total number of events recorded.
+func (m *Meter) Count() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count
+}
+
+// Returns the mean rate in events per second since the meter was created.
+func (m *Meter) MeanRate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elapsed := m.clock.Now().Sub(m.start)
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(m.count) / elapsed.Seconds()
+}
+
+// Returns the one-minute moving average rate, in events per second.
+func (m *Meter) Rate1() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickIfNecessary()
+	return m.m1.Rate()
+}
+
+// Returns the five-minute moving average rate, in events per second.
+func (m *Meter) Rate5() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickIfNecessary()
+	return m.m5.Rate()
+}
+
+// Returns the fifteen-minute moving average rate, in events per second.
+func (m *Meter) Rate15() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickIfNecessary()
+	return m.m15.Rate()
+}
+
+// Returns the number of events recorded within the sliding window.
+func (m *Meter) WindowCount() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.window.Count()
+}
+
+// Returns the rate over the sliding window, in events per second.
+func (m *Meter) WindowRate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.window.Rate()
+}
@@ -0,0 +1,57 @@
+package rate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeter(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	m := NewMeter(time.Minute, 60, clk)
+
+	// Ten events per second for two minutes.
+	for i := 0; i < 120; i++ {
+		m.Mark(10)
+		clk.Advance(time.Second)
+	}
+
+	assert.Equal(t, int64(1200), m.Count())
+	assert.InDelta(t, 10, m.MeanRate(), 1e-9)
+	assert.InDelta(t, 10, m.Rate1(), 1e-9)
+	assert.InDelta(t, 10, m.Rate5(), 1e-9)
+	assert.InDelta(t, 10, m.Rate15(), 1e-9)
+	assert.Equal(t, int64(590), m.WindowCount())
+
+	// After a minute of silence, the window is empty and the moving averages
+	// decay.
+	clk.Advance(time.Minute)
+	assert.Equal(t, int64(0), m.WindowCount())
+	assert.Equal(t, 0.0, m.WindowRate())
+	assert.InDelta(t, 10*0.36787944, m.Rate1(), 1e-6)
+	assert.Less(t, m.Rate1(), m.Rate5())
+	assert.Less(t, m.Rate5(), m.Rate15())
+	assert.InDelta(t, 1200.0/180, m.MeanRate(), 1e-9)
+}
+
+func TestMeterConcurrent(t *testing.T) {
+	m := NewMeter(time.Minute, 60, clock.NewFake(testStart))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.Mark(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(8000), m.Count())
+	assert.Equal(t, int64(8000), m.WindowCount())
+}
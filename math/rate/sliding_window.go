@@ -0,0 +1,103 @@
+package rate
+
+import (
+	"math"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+)
+
+// Counts events over a sliding window of time. The window is divided into
+// sub-buckets, and events expire a whole bucket at a time, so the count covers
+// between window - window/buckets and window worth of events. More buckets
+// give a smoother window at the cost of memory.
+//
+// Not safe for concurrent use; see Meter for a thread-safe wrapper.
+type SlidingWindowCounter struct {
+	clock       clock.Clock
+	bucketWidth time.Duration
+	buckets     []windowBucket
+}
+
+// Marks a bucket that holds no events.
+const expiredEpoch = math.MinInt64
+
+type windowBucket struct {
+	// The number of bucket widths since the Unix epoch at which this bucket
+	// starts.
+	epoch int64
+	count int64
+}
+
+// Returns a counter over the given window, divided into the given number of
+// buckets. If clk is nil, the system clock is used. Panics if window is not
+// positive or smaller than the number of buckets in nanoseconds, or if buckets
+// is not positive.
+func NewSlidingWindowCounter(window time.Duration, buckets int, clk clock.Clock) *SlidingWindowCounter {
+	if buckets <= 0 {
+		panic("rate: number of buckets must be positive")
+	}
+	bucketWidth := window / time.Duration(buckets)
+	if bucketWidth <= 0 {
+		panic("rate: window is too small for the number of buckets")
+	}
+
+	c := &SlidingWindowCounter{
+		clock:       clock.OrReal(clk),
+		bucketWidth: bucketWidth,
+		buckets:     make([]windowBucket, buckets),
+	}
+
+	c.Reset()
+	return c
+}
+
+// Returns the duration covered by the window.
+func (c *SlidingWindowCounter) Window() time.Duration {
+	return c.bucketWidth * time.Duration(len(c.buckets))
+}
+
+func (c *SlidingWindowCounter) currentEpoch() int64 {
+	return c.clock.Now().UnixNano() / int64(c.bucketWidth)
+}
+
+// Records n events at the current time.
+func (c *SlidingWindowCounter) Add(n int64) {
+	epoch := c.currentEpoch()
+	i := epoch % int64(len(c.buckets))
+	if i < 0 {
+		i += int64(len(c.buckets))
+	}
+	b := &c.buckets[i]
+	if b.epoch != epoch {
+		b.epoch = epoch
+		b.count = 0
+	}
+	b.count += n
+}
+
+// Returns the number of events recorded within the window.
+func (c *SlidingWindowCounter) Count() int64 {
+	epoch := c.currentEpoch()
+	oldest := epoch - int64(len(c.buckets)) + 1
+
+	var count int64
+	for _, b := range c.buckets {
+		if oldest <= b.epoch && b.epoch <= epoch {
+			count += b.count
+		}
+	}
+	return count
+}
+
+// Returns the number of events recorded within the window, per second.
+func (c *SlidingWindowCounter) Rate() float64 {
+	return float64(c.Count()) / c.Window().Seconds()
+}
+
+// Discards all recorded events.
+func (c *SlidingWindowCounter) Reset() {
+	for i := range c.buckets {
+		c.buckets[i] = windowBucket{epoch: expiredEpoch}
+	}
+}
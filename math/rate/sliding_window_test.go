@@ -0,0 +1,49 @@
+package rate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSlidingWindowCounter(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	c := NewSlidingWindowCounter(10*time.Second, 10, clk)
+	assert.Equal(t, 10*time.Second, c.Window())
+	assert.Equal(t, int64(0), c.Count())
+
+	// One event per second for 10 seconds fills the window.
+	for i := 0; i < 10; i++ {
+		c.Add(1)
+		clk.Advance(time.Second)
+	}
+	assert.Equal(t, int64(9), c.Count(), "the oldest bucket has expired")
+
+	clk.Set(testStart.Add(9 * time.Second))
+	assert.Equal(t, int64(10), c.Count())
+	assert.InDelta(t, 1.0, c.Rate(), 1e-9)
+
+	// Events expire one bucket at a time.
+	clk.Advance(5 * time.Second)
+	assert.Equal(t, int64(5), c.Count())
+
+	// Buckets are reused once they expire.
+	c.Add(7)
+	assert.Equal(t, int64(12), c.Count())
+
+	clk.Advance(time.Hour)
+	assert.Equal(t, int64(0), c.Count())
+
+	c.Add(3)
+	c.Reset()
+	assert.Equal(t, int64(0), c.Count())
+}
+
+func TestSlidingWindowCounterPanics(t *testing.T) {
+	assert.Panics(t, func() { NewSlidingWindowCounter(time.Second, 0, nil) })
+	assert.Panics(t, func() { NewSlidingWindowCounter(5, 10, nil) })
+}
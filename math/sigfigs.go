@@ -2,37 +2,126 @@ package math
 
 import (
 	"math"
+	"strconv"
+	"unsafe"
+
+	"github.com/akitasoftware/go-utils/internal/decimal"
 )
 
-// Rounds the given number to n significant figures.
-func RoundToSigFigs[T float32 | float64](x T, n int) T {
-	if x == 0 {
-		return 0
-	}
+// Determines how a number is rounded when it lies between two representable
+// results.
+type RoundingMode int
+
+const (
+	// Rounds to the nearest result, with ties rounded away from zero, as
+	// math.Round does.
+	HalfAwayFromZero = RoundingMode(decimal.HalfAwayFromZero)
+
+	// Rounds to the nearest result, with ties rounded to the result whose last
+	// digit is even, as math.RoundToEven does.
+	HalfEven = RoundingMode(decimal.HalfEven)
+
+	// Rounds towards negative infinity.
+	Floor = RoundingMode(decimal.Floor)
 
-	scale := math.Pow10(n - int(math.Ceil(math.Log10(math.Abs(float64(x))))))
+	// Rounds towards positive infinity.
+	Ceil = RoundingMode(decimal.Ceil)
 
-	return T(math.Round(float64(x)*scale) / scale)
+	// Rounds towards zero, discarding the excess digits.
+	TowardZero = RoundingMode(decimal.TowardZero)
+)
+
+// Rounds the given number to n significant figures, with ties rounded away
+// from zero.
+//
+// Rounding is decimal-exact: the number is treated as the shortest decimal
+// that converts back to it, so RoundToSigFigs(0.1+0.2, 3) is exactly 0.3 and
+// RoundToSigFigs(2.345, 3) is 2.35, even though neither 0.1+0.2 nor 2.345 is
+// exactly representable. Magnitude is not limited, so subnormals and numbers
+// near the largest float64 are handled. If the rounded result is too large to
+// represent, ±Inf is returned.
+//
+// If n is zero or negative, the number is rounded to a multiple of a power of
+// ten larger than itself; for example, RoundToSigFigs(7, 0) is 10, and
+// RoundToSigFigs(7, -1) is 0.
+func RoundToSigFigs[T float32 | float64](x T, n int) T {
+	return RoundToSigFigsWithMode(x, n, HalfAwayFromZero)
 }
 
-// Rounds the given number down to n significant figures.
+// Rounds the given number down to n significant figures. See RoundToSigFigs.
 func FloorToSigFigs[T float32 | float64](x T, n int) T {
-	if x == 0 {
-		return 0
-	}
+	return RoundToSigFigsWithMode(x, n, Floor)
+}
 
-	scale := math.Pow10(n - int(math.Ceil(math.Log10(math.Abs(float64(x))))))
+// Rounds the given number up to n significant figures. See RoundToSigFigs.
+func CeilToSigFigs[T float32 | float64](x T, n int) T {
+	return RoundToSigFigsWithMode(x, n, Ceil)
+}
 
-	return T(math.Floor(float64(x)*scale) / scale)
+// Rounds the given number to n significant figures, using the given rounding
+// mode. See RoundToSigFigs. Panics if mode is not one of the RoundingMode
+// constants.
+func RoundToSigFigsWithMode[T float32 | float64](x T, n int, mode RoundingMode) T {
+	return roundDecimal(x, mode, func(exp int) int { return n })
 }
 
-// Rounds the given number up to n significant figures.
-func CeilToSigFigs[T float32 | float64](x T, n int) T {
-	if x == 0 {
-		return 0
+// Rounds the given number to the given number of digits after the decimal
+// point, with ties rounded away from zero. If places is negative, the number
+// is rounded to a multiple of a power of ten; for example,
+// RoundToDecimalPlaces(1234, -2) is 1200. Like RoundToSigFigs, rounding is
+// decimal-exact, so RoundToDecimalPlaces(1.005, 2) is 1.01.
+func RoundToDecimalPlaces[T float32 | float64](x T, places int) T {
+	return RoundToDecimalPlacesWithMode(x, places, HalfAwayFromZero)
+}
+
+// Rounds the given number to the given number of digits after the decimal
+// point, using the given rounding mode. See RoundToDecimalPlaces. Panics if
+// mode is not one of the RoundingMode constants.
+func RoundToDecimalPlacesWithMode[T float32 | float64](x T, places int, mode RoundingMode) T {
+	// A number whose first digit is in the 10^exp place has exp+1 digits
+	// before the decimal point. Clamp rather than overflow: a huge number of
+	// places keeps every digit, and a hugely negative one discards them all.
+	return roundDecimal(x, mode, func(exp int) int {
+		switch {
+		case places > 0 && exp+1 > math.MaxInt-places:
+			return math.MaxInt
+		case places < 0 && exp+1 < math.MinInt-places:
+			return math.MinInt
+		}
+		return exp + 1 + places
+	})
+}
+
+// Rounds x to the number of significant figures returned by sigFigs, which is
+// given the decimal exponent of x's first digit.
+func roundDecimal[T float32 | float64](x T, mode RoundingMode, sigFigs func(exp int) int) T {
+	if mode < HalfAwayFromZero || mode > TowardZero {
+		panic("math: unknown rounding mode")
+	}
+	if x == 0 || math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+		return x
 	}
 
-	scale := math.Pow10(n - int(math.Ceil(math.Log10(math.Abs(float64(x))))))
+	var zero T
+	bitSize := int(unsafe.Sizeof(zero)) * 8
+
+	// Build the rounded number as a decimal literal on the stack, so that
+	// rounding doesn't allocate.
+	var buf [64]byte
+	number := buf[:1]
+	number[0] = '-'
+	if x > 0 {
+		number = number[:0]
+	}
+	digits, exp := decimal.Round(buf[len(number):len(number)], float64(x), bitSize, decimal.Mode(mode), sigFigs)
+	if len(digits) == 0 {
+		return 0
+	}
 
-	return T(math.Ceil(float64(x)*scale) / scale)
+	// The last digit is in the 10^(exp-len(digits)+1) place. Parsing rounds to
+	// the nearest representable number, and returns ±Inf on overflow.
+	number = append(number[:len(number)+len(digits)], 'e')
+	number = strconv.AppendInt(number, int64(exp-len(digits)+1), 10)
+	rv, _ := strconv.ParseFloat(string(number), bitSize)
+	return T(rv)
 }
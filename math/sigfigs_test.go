@@ -2,7 +2,10 @@ package math
 
 import (
 	"math"
+	"math/rand"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRoundTo3SigFigs(t *testing.T) {
@@ -97,12 +100,11 @@ func TestCeilTo3SigFigs(t *testing.T) {
 		0.7:    0.7,
 		0.0042: 0.0042,
 
-		// XXX Some of the scaled inputs used in this test can't be represented
-		// precisely as IEEE floating point numbers. This imprecision can cause
-		// the ceiling operation to return an unexpected result. Fix the expected
-		// result in these cases so that it matches the actual result.
-		100000000: 100100000,
-		0.07:      0.0701,
+		// Before rounding was decimal-exact, the ceiling operation returned
+		// 100100000 and 0.0701 for these inputs, so their expected results
+		// were overridden to match. They are now rounded correctly.
+		100000000: 100000000,
+		0.07:      0.07,
 	}
 
 	epsilon := 1e-7
@@ -181,12 +183,11 @@ func TestFloorTo3SigFigs(t *testing.T) {
 		-0.7:    -0.7,
 		-0.0042: -0.0042,
 
-		// XXX Some of the scaled inputs used in this test can't be represented
-		// precisely as IEEE floating point numbers. This imprecision can cause
-		// the floor operation to return an unexpected result. Fix the expected
-		// result in these cases so that it matches the actual result.
-		-0.07:      -0.0701,
-		-100000000: -100100000,
+		// Before rounding was decimal-exact, the floor operation returned
+		// -0.0701 and -100100000 for these inputs, so their expected results
+		// were overridden to match. They are now rounded correctly.
+		-0.07:      -0.07,
+		-100000000: -100000000,
 	}
 
 	epsilon := 1e-7
@@ -211,3 +212,158 @@ func TestFloorTo3SigFigs(t *testing.T) {
 		}
 	}
 }
+
+func TestRoundToSigFigsDecimalExact(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actual   float64
+		expected float64
+	}{
+		{"sum of tenths", RoundToSigFigs(0.1+0.2, 3), 0.3},
+		{"inexact tie", RoundToSigFigs(2.345, 3), 2.35},
+		{"inexact ceil", CeilToSigFigs(0.07, 3), 0.07},
+		{"inexact floor", FloorToSigFigs(-0.07, 3), -0.07},
+		{"power of ten ceil", CeilToSigFigs(1e8, 3), 1e8},
+		{"power of ten floor", FloorToSigFigs(-1e8, 3), -1e8},
+		{"subnormal", RoundToSigFigs(1.23456e-310, 3), 1.23e-310},
+		{"smallest subnormal", RoundToSigFigs(5e-324, 1), 5e-324},
+		{"near max", FloorToSigFigs(math.MaxFloat64, 3), 1.79e308},
+		{"overflow", RoundToSigFigs(math.MaxFloat64, 3), math.Inf(1)},
+		{"negative overflow", FloorToSigFigs(-math.MaxFloat64, 2), math.Inf(-1)},
+		{"zero sig figs", RoundToSigFigs(7.0, 0), 10},
+		{"zero sig figs rounding down", RoundToSigFigs(3.0, 0), 0},
+		{"negative sig figs", RoundToSigFigs(7.0, -1), 0},
+		{"negative sig figs ceil", CeilToSigFigs(7.0, -1), 100},
+		{"negative sig figs floor", FloorToSigFigs(-7.0, -1), -100},
+		{"carry", RoundToSigFigs(9.996, 3), 10},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.actual, tc.name)
+	}
+
+	assert.True(t, math.IsNaN(RoundToSigFigs(math.NaN(), 3)))
+	assert.Equal(t, math.Inf(-1), RoundToSigFigs(math.Inf(-1), 3))
+	assert.Equal(t, float32(0.3), RoundToSigFigs(float32(0.1)+float32(0.2), 3))
+	assert.Equal(t, float32(1.23e38), RoundToSigFigs(float32(1.2345e38), 3))
+}
+
+func TestRoundingModes(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		mode     RoundingMode
+		expected float64
+	}{
+		{2.5, HalfAwayFromZero, 3},
+		{-2.5, HalfAwayFromZero, -3},
+		{2.5, HalfEven, 2},
+		{3.5, HalfEven, 4},
+		{-2.5, HalfEven, -2},
+		{2.51, HalfEven, 3},
+		{2.5, Floor, 2},
+		{-2.5, Floor, -3},
+		{2.5, Ceil, 3},
+		{-2.5, Ceil, -2},
+		{2.9, TowardZero, 2},
+		{-2.9, TowardZero, -2},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, RoundToSigFigsWithMode(tc.x, 1, tc.mode), "%v with mode %d", tc.x, tc.mode)
+	}
+}
+
+func TestRoundToDecimalPlaces(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		places   int
+		mode     RoundingMode
+		expected float64
+	}{
+		{1.005, 2, HalfAwayFromZero, 1.01},
+		{0.125, 2, HalfAwayFromZero, 0.13},
+		{0.125, 2, HalfEven, 0.12},
+		{0.135, 2, HalfEven, 0.14},
+		{1234.5678, 1, HalfAwayFromZero, 1234.6},
+		{1234.5678, 0, HalfAwayFromZero, 1235},
+		{1234.5678, -2, HalfAwayFromZero, 1200},
+		{1234.5678, -4, HalfAwayFromZero, 0},
+		{5678, -4, HalfAwayFromZero, 10000},
+		{0.0004, 3, HalfAwayFromZero, 0},
+		{0.0005, 3, HalfAwayFromZero, 0.001},
+		{-0.0005, 3, Ceil, 0},
+		{-0.0005, 3, Floor, -0.001},
+		{1.5, 3, HalfAwayFromZero, 1.5},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, RoundToDecimalPlacesWithMode(tc.x, tc.places, tc.mode), "%v to %d places with mode %d", tc.x, tc.places, tc.mode)
+	}
+	assert.Equal(t, 2.68, RoundToDecimalPlaces(2.675, 2))
+}
+
+func TestRoundingExtremeArguments(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actual   float64
+		expected float64
+	}{
+		{"all places", RoundToDecimalPlaces(123.456, math.MaxInt), 123.456},
+		{"no places", RoundToDecimalPlaces(123.456, math.MinInt), 0},
+		{"no places ceil", RoundToDecimalPlacesWithMode(123.456, math.MinInt, Ceil), math.Inf(1)},
+		{"no places floor", RoundToDecimalPlacesWithMode(123.456, math.MinInt, Floor), 0},
+		{"no places negative floor", RoundToDecimalPlacesWithMode(-123.456, math.MinInt, Floor), math.Inf(-1)},
+		{"all sig figs", RoundToSigFigs(123.456, math.MaxInt), 123.456},
+		{"no sig figs", RoundToSigFigs(7.0, math.MinInt), 0},
+		{"no sig figs half even", RoundToSigFigsWithMode(7.0, math.MinInt, HalfEven), 0},
+		{"no sig figs toward zero", RoundToSigFigsWithMode(7.0, -5, TowardZero), 0},
+		{"no sig figs ceil", CeilToSigFigs(7.0, math.MinInt), math.Inf(1)},
+		{"negative sig figs ceil", CeilToSigFigs(7.0, -5), 1e6},
+		{"negative sig figs ceil overflow", CeilToSigFigs(7.0, -400), math.Inf(1)},
+		{"negative sig figs floor", FloorToSigFigs(7.0, -5), 0},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.actual, tc.name)
+	}
+	assert.Equal(t, float32(math.Inf(1)), CeilToSigFigs(float32(7), -40))
+}
+
+func TestInvalidRoundingMode(t *testing.T) {
+	for _, mode := range []RoundingMode{-1, TowardZero + 1} {
+		assert.Panics(t, func() { RoundToSigFigsWithMode(1.5, 1, mode) }, "mode %d", mode)
+		assert.Panics(t, func() { RoundToSigFigsWithMode(0.0, 1, mode) }, "mode %d with zero", mode)
+		assert.Panics(t, func() { RoundToDecimalPlacesWithMode(1.5, 0, mode) }, "mode %d", mode)
+	}
+}
+
+// The scale-and-round approach that RoundToSigFigs used before it was
+// decimal-exact, kept to compare performance.
+func roundToSigFigsByScaling(x float64, n int) float64 {
+	if x == 0 {
+		return 0
+	}
+	scale := math.Pow10(n - int(math.Ceil(math.Log10(math.Abs(x)))))
+	return math.Round(x*scale) / scale
+}
+
+var sigFigsInputs = func() []float64 {
+	rng := rand.New(rand.NewSource(1))
+	rv := make([]float64, 1024)
+	for i := range rv {
+		rv[i] = rng.NormFloat64() * math.Pow10(rng.Intn(20)-10)
+	}
+	return rv
+}()
+
+var sigFigsSink float64
+
+func BenchmarkRoundToSigFigs(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sigFigsSink = RoundToSigFigs(sigFigsInputs[i%len(sigFigsInputs)], 3)
+	}
+}
+
+func BenchmarkRoundToSigFigsByScaling(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sigFigsSink = roundToSigFigsByScaling(sigFigsInputs[i%len(sigFigsInputs)], 3)
+	}
+}
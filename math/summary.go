@@ -0,0 +1,225 @@
+package math
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/akitasoftware/go-utils/constraints"
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+// Incrementally summarizes a stream of values, tracking their count, sum,
+// minimum, maximum, mean and variance in constant memory. The mean and variance
+// are computed using Welford's algorithm, which is numerically stable.
+//
+// The zero value is an empty summary.
+type Summary[T constraints.Number] struct {
+	count int64
+	sum   T
+	min   T
+	max   T
+	mean  float64
+
+	// The sum of squared differences from the mean.
+	m2 float64
+
+	// If positive, MarshalJSON rounds each statistic to this many significant
+	// figures.
+	sigFigs int
+}
+
+// Returns a new summary of the given values.
+func NewSummary[T constraints.Number](values ...T) *Summary[T] {
+	s := &Summary[T]{}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *Summary[T]) Add(x T) {
+	if s.count == 0 || x < s.min {
+		s.min = x
+	}
+	if s.count == 0 || x > s.max {
+		s.max = x
+	}
+
+	s.count++
+	s.sum += x
+
+	delta := float64(x) - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (float64(x) - s.mean)
+}
+
+// Adds the values summarized by other to this summary, as if each had been
+// passed to Add. This allows summaries computed over separate shards of a
+// stream to be combined.
+func (s *Summary[T]) Merge(other *Summary[T]) {
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		sigFigs := s.sigFigs
+		*s = *other
+		s.sigFigs = sigFigs
+		return
+	}
+
+	s.min = Min(s.min, other.min)
+	s.max = Max(s.max, other.max)
+	s.sum += other.sum
+
+	// Chan et al.'s parallel variant of Welford's algorithm.
+	count := s.count + other.count
+	delta := other.mean - s.mean
+	s.mean += delta * float64(other.count) / float64(count)
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/float64(count)
+	s.count = count
+}
+
+func (s *Summary[T]) Count() int64 {
+	return s.count
+}
+
+func (s *Summary[T]) Sum() T {
+	return s.sum
+}
+
+// Returns None if the summary is empty.
+func (s *Summary[T]) Min() optionals.Optional[T] {
+	if s.count == 0 {
+		return optionals.None[T]()
+	}
+	return optionals.Some(s.min)
+}
+
+// Returns None if the summary is empty.
+func (s *Summary[T]) Max() optionals.Optional[T] {
+	if s.count == 0 {
+		return optionals.None[T]()
+	}
+	return optionals.Some(s.max)
+}
+
+// Returns None if the summary is empty.
+func (s *Summary[T]) Mean() optionals.Optional[float64] {
+	if s.count == 0 {
+		return optionals.None[float64]()
+	}
+	return optionals.Some(s.mean)
+}
+
+// Returns the population variance. Returns 0 if the summary is empty.
+func (s *Summary[T]) Variance() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.count)
+}
+
+// Returns the sample variance, using Bessel's correction. Returns 0 if the
+// summary has fewer than two values.
+func (s *Summary[T]) SampleVariance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// Returns the population standard deviation. Returns 0 if the summary is empty.
+func (s *Summary[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Causes MarshalJSON to round each statistic to n significant figures. If n is
+// not positive, statistics are not rounded.
+func (s *Summary[T]) RoundOutputToSigFigs(n int) {
+	s.sigFigs = n
+}
+
+type summaryJSON[T constraints.Number] struct {
+	Count    int64                       `json:"count"`
+	Sum      T                           `json:"sum"`
+	Min      optionals.Optional[T]       `json:"min"`
+	Max      optionals.Optional[T]       `json:"max"`
+	Mean     optionals.Optional[float64] `json:"mean"`
+	Variance float64                     `json:"variance"`
+	StdDev   float64                     `json:"stddev"`
+}
+
+func (s *Summary[T]) MarshalJSON() ([]byte, error) {
+	round := func(x float64) float64 {
+		if s.sigFigs <= 0 {
+			return x
+		}
+		return RoundToSigFigs(x, s.sigFigs)
+	}
+	roundT := func(x T) T {
+		if s.sigFigs <= 0 {
+			return x
+		}
+		return roundNumberToSigFigs(x, s.sigFigs)
+	}
+
+	return json.Marshal(summaryJSON[T]{
+		Count:    s.count,
+		Sum:      roundT(s.sum),
+		Min:      optionals.Map(s.Min(), roundT),
+		Max:      optionals.Map(s.Max(), roundT),
+		Mean:     optionals.Map(s.Mean(), round),
+		Variance: round(s.Variance()),
+		StdDev:   round(s.StdDev()),
+	})
+}
+
+// Decodes a summary encoded by MarshalJSON. If the summary was rounded, this
+// doesn't give back the original: the decoded statistics are the rounded ones,
+// the variance is rebuilt from the rounded variance and count, and the number
+// of significant figures isn't kept, so the decoded summary marshals without
+// rounding.
+func (s *Summary[T]) UnmarshalJSON(text []byte) error {
+	var decoded summaryJSON[T]
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal summary")
+	}
+
+	*s = Summary[T]{
+		count: decoded.Count,
+		sum:   decoded.Sum,
+		min:   decoded.Min.GetOrDefault(0),
+		max:   decoded.Max.GetOrDefault(0),
+		mean:  decoded.Mean.GetOrDefault(0),
+		m2:    decoded.Variance * float64(decoded.Count),
+	}
+	return nil
+}
+
+// Rounds x to n significant figures. Integers are only converted through
+// float64 when that changes their value, so integers beyond 2^53 that already
+// have at most n significant figures are kept exactly. If the rounded value is
+// out of T's range, as when the uint8 255 is rounded to 260, x is returned
+// unrounded.
+func roundNumberToSigFigs[T constraints.Number](x T, n int) T {
+	f := float64(x)
+	rounded := RoundToSigFigs(f, n)
+	if rounded == f {
+		return x
+	}
+
+	rv := T(rounded)
+	if isIntegral[T]() && float64(rv) != rounded {
+		// Converting an out-of-range float to an integer type doesn't panic,
+		// but gives an implementation-defined value.
+		return x
+	}
+	return rv
+}
+
+// Returns whether T is an integer type.
+func isIntegral[T constraints.Number]() bool {
+	half := 0.5
+	return T(half) == 0
+}
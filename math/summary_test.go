@@ -0,0 +1,125 @@
+package math
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	s := NewSummary(2, 4, 4, 4, 5, 5, 7, 9)
+
+	assert.Equal(t, int64(8), s.Count())
+	assert.Equal(t, 40, s.Sum())
+	assert.Equal(t, optionals.Some(2), s.Min())
+	assert.Equal(t, optionals.Some(9), s.Max())
+	assert.Equal(t, optionals.Some(5.0), s.Mean())
+	assert.InDelta(t, 4.0, s.Variance(), 1e-9)
+	assert.InDelta(t, 32.0/7, s.SampleVariance(), 1e-9)
+	assert.InDelta(t, 2.0, s.StdDev(), 1e-9)
+}
+
+func TestEmptySummary(t *testing.T) {
+	var s Summary[float64]
+
+	assert.Equal(t, int64(0), s.Count())
+	assert.Equal(t, optionals.None[float64](), s.Min())
+	assert.Equal(t, optionals.None[float64](), s.Max())
+	assert.Equal(t, optionals.None[float64](), s.Mean())
+	assert.Equal(t, 0.0, s.Variance())
+	assert.Equal(t, 0.0, s.SampleVariance())
+}
+
+func TestSummaryMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]float64, 10000)
+	for i := range values {
+		values[i] = rng.ExpFloat64() * 100
+	}
+
+	whole := NewSummary(values...)
+
+	// Split into uneven shards, including an empty one.
+	merged := NewSummary[float64]()
+	for _, shard := range [][]float64{values[:0], values[:17], values[17:5000], values[5000:]} {
+		merged.Merge(NewSummary(shard...))
+	}
+
+	assert.Equal(t, whole.Count(), merged.Count())
+	assert.InDelta(t, whole.Sum(), merged.Sum(), 1e-6)
+	assert.Equal(t, whole.Min(), merged.Min())
+	assert.Equal(t, whole.Max(), merged.Max())
+	assert.InDelta(t, whole.Mean().GetOrDefault(0), merged.Mean().GetOrDefault(0), 1e-9)
+	assert.InDelta(t, whole.Variance(), merged.Variance(), 1e-6)
+}
+
+func TestSummaryStability(t *testing.T) {
+	// A naive sum-of-squares computation loses all precision here.
+	s := NewSummary[float64]()
+	for _, x := range []float64{4, 7, 13, 16} {
+		s.Add(1e9 + x)
+	}
+	assert.InDelta(t, 22.5, s.Variance(), 1e-6)
+}
+
+func TestSummaryJSON(t *testing.T) {
+	s := NewSummary(1234, 5678)
+
+	bs, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count":2,"sum":6912,"min":1234,"max":5678,"mean":3456,"variance":4937284,"stddev":2222}`, string(bs))
+
+	var deserialized Summary[int]
+	err = json.Unmarshal(bs, &deserialized)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Count(), deserialized.Count())
+	assert.Equal(t, s.Min(), deserialized.Min())
+	assert.InDelta(t, s.Variance(), deserialized.Variance(), 1e-9)
+
+	s.RoundOutputToSigFigs(2)
+	bs, err = json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count":2,"sum":6900,"min":1200,"max":5700,"mean":3500,"variance":4900000,"stddev":2200}`, string(bs))
+
+	bs, err = json.Marshal(NewSummary[float64]())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count":0,"sum":0,"min":null,"max":null,"mean":null,"variance":0,"stddev":0}`, string(bs))
+}
+
+func TestSummaryJSONLargeIntegers(t *testing.T) {
+	// Values beyond 2^53 can't be represented exactly as float64s.
+	s := NewSummary[int64](1<<53+1, math.MaxInt64)
+
+	bs, err := json.Marshal(s)
+	assert.NoError(t, err)
+	var decoded summaryJSON[int64]
+	assert.NoError(t, json.Unmarshal(bs, &decoded))
+	assert.Equal(t, optionals.Some[int64](1<<53+1), decoded.Min)
+	assert.Equal(t, optionals.Some[int64](math.MaxInt64), decoded.Max)
+
+	// MaxInt64 rounds to a value beyond the int64 range, so it is kept as is.
+	s.RoundOutputToSigFigs(15)
+	bs, err = json.Marshal(s)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(bs, &decoded))
+	assert.Equal(t, optionals.Some[int64](9007199254740990), decoded.Min)
+	assert.Equal(t, optionals.Some[int64](math.MaxInt64), decoded.Max)
+
+	// Rounding that leaves the value unchanged keeps it exactly.
+	s.RoundOutputToSigFigs(19)
+	bs, err = json.Marshal(s)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(bs, &decoded))
+	assert.Equal(t, optionals.Some[int64](1<<53+1), decoded.Min)
+	assert.Equal(t, optionals.Some[int64](math.MaxInt64), decoded.Max)
+
+	small := NewSummary[uint8](255)
+	small.RoundOutputToSigFigs(2)
+	bs, err = json.Marshal(small)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count":1,"sum":255,"min":255,"max":255,"mean":260,"variance":0,"stddev":0}`, string(bs))
+}
@@ -0,0 +1,126 @@
+package optionals
+
+// A Nullable[T] distinguishes between three states of a serialized field: the
+// field was absent, the field was explicitly null, or the field held a value.
+// This is useful for PATCH-style APIs, where an absent field means "leave
+// unchanged" and a null field means "clear".
+//
+// The zero value is Absent. Nullables are comparable with == when T is, and
+// constructing one does not allocate unless T's value does.
+//
+// When serializing, Absent and Null both marshal as null. To omit Absent
+// fields entirely:
+//   - With yaml.v2, use the `omitempty` tag, which consults IsZero.
+//   - With encoding/json on Go 1.24 or later, use the `omitzero` tag, which
+//     also consults IsZero.
+//   - With encoding/json on earlier versions of Go, `omitempty` has no effect
+//     on struct fields, so it only works through a *Nullable[T] field, which
+//     is omitted when nil. Decoding leaves such a field nil for both a missing
+//     key and an explicit null, so declare separate types for encoding and
+//     decoding if both matter.
+//
+// When deserializing JSON, a field whose key is missing is left Absent, an
+// explicit null becomes Null, and anything else becomes a value.
+//
+// When deserializing YAML, an explicit null is decoded as Absent, not Null:
+// yaml.v2 does not invoke unmarshalers on null nodes and instead resets the
+// field to its zero value, so an explicit null is indistinguishable from a
+// missing key.
+type Nullable[T any] struct {
+	// Whether the field was present in the serialized form.
+	present bool
+
+	// The value of the field. None if the field was null or absent.
+	value Value[T]
+}
+
+// Returns a Nullable representing an absent field.
+func Absent[T any]() Nullable[T] {
+	return Nullable[T]{}
+}
+
+// Returns a Nullable representing an explicit null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{
+		present: true,
+	}
+}
+
+// Returns a Nullable holding the given value.
+func Present[T any](t T) Nullable[T] {
+	return Nullable[T]{
+		present: true,
+		value:   SomeValue(t),
+	}
+}
+
+// Converts an Optional to a Nullable. None becomes Null, and Some(t) becomes
+// Present(t).
+func FromOptional[T any](opt Optional[T]) Nullable[T] {
+	return Nullable[T]{
+		present: true,
+		value:   ToValue(opt),
+	}
+}
+
+// Returns true if the field was absent.
+func (n Nullable[T]) IsAbsent() bool {
+	return !n.present
+}
+
+// Returns true if the field was explicitly null.
+func (n Nullable[T]) IsNull() bool {
+	return n.present && n.value.IsNone()
+}
+
+// Returns true if the field held a value.
+func (n Nullable[T]) IsPresent() bool {
+	return n.value.IsSome()
+}
+
+// Returns true if the field was absent. Used by yaml.v2's `omitempty` and, on
+// Go 1.24 and later, encoding/json's `omitzero` to omit absent fields.
+func (n Nullable[T]) IsZero() bool {
+	return n.IsAbsent()
+}
+
+// Returns the value held by this Nullable. The boolean result is false if the
+// field was absent or null.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.value.Get()
+}
+
+// Converts to an Optional. Absent and Null both become None.
+func (n Nullable[T]) ToOptional() Optional[T] {
+	return n.value.ToOptional()
+}
+
+// Absent and Null both marshal as null. See the Nullable documentation for how
+// to omit absent fields.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	return n.value.MarshalJSON()
+}
+
+// Only called by encoding/json when the field is present.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	var value Value[T]
+	if err := value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*n = Nullable[T]{present: true, value: value}
+	return nil
+}
+
+func (n Nullable[T]) MarshalYAML() (interface{}, error) {
+	return n.value.MarshalYAML()
+}
+
+// Only called by yaml.v2 when the field is present and non-null.
+func (n *Nullable[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value Value[T]
+	if err := value.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	*n = Nullable[T]{present: true, value: value}
+	return nil
+}
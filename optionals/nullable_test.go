@@ -0,0 +1,153 @@
+package optionals
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+type patchRequest struct {
+	Name Nullable[string] `json:"name" yaml:"name,omitempty"`
+}
+
+// Before Go 1.24, encoding/json can only omit a Nullable through a pointer.
+type patchResponse struct {
+	Name *Nullable[string] `json:"name,omitempty"`
+}
+
+func TestNullableJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected Nullable[string]
+	}{
+		{
+			name:     "absent",
+			json:     `{}`,
+			expected: Absent[string](),
+		},
+		{
+			name:     "null",
+			json:     `{"name":null}`,
+			expected: Null[string](),
+		},
+		{
+			name:     "present",
+			json:     `{"name":"foo"}`,
+			expected: Present("foo"),
+		},
+	}
+
+	for _, tc := range testCases {
+		// Test that deserialize(json) == expected.
+		var deserialized patchRequest
+		err := json.Unmarshal([]byte(tc.json), &deserialized)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, deserialized.Name, tc.name)
+
+		// Test that serialize(expected) == json, omitting the field if it's
+		// absent.
+		var response patchResponse
+		if !tc.expected.IsAbsent() {
+			response.Name = &tc.expected
+		}
+		serialized, err := json.Marshal(response)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.json, string(serialized), tc.name)
+	}
+
+	// Without omitzero, which needs Go 1.24, an absent field marshals as null.
+	serialized, err := json.Marshal(patchRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":null}`, string(serialized))
+	assert.True(t, Absent[string]().IsZero())
+	assert.False(t, Null[string]().IsZero())
+}
+
+func TestNullableYAML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		yaml     string
+		expected Nullable[string]
+	}{
+		{
+			name:     "absent",
+			yaml:     "{}\n",
+			expected: Absent[string](),
+		},
+		{
+			name:     "present",
+			yaml:     "name: foo\n",
+			expected: Present("foo"),
+		},
+	}
+
+	for _, tc := range testCases {
+		var deserialized patchRequest
+		err := yaml.Unmarshal([]byte(tc.yaml), &deserialized)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, deserialized.Name, tc.name)
+
+		serialized, err := yaml.Marshal(patchRequest{Name: tc.expected})
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.yaml, string(serialized), tc.name)
+	}
+
+	// Null marshals as null.
+	serialized, err := yaml.Marshal(patchRequest{Name: Null[string]()})
+	assert.NoError(t, err)
+	assert.Equal(t, "name: null\n", string(serialized))
+
+	// yaml.v2 doesn't call unmarshalers on null nodes, so an explicit null
+	// can't be told apart from an absent field, and decodes as Absent.
+	for _, null := range []string{"name: null\n", "name: ~\n", "name:\n"} {
+		deserialized := patchRequest{Name: Present("foo")}
+		err := yaml.Unmarshal([]byte(null), &deserialized)
+		assert.NoError(t, err, null)
+		assert.True(t, deserialized.Name.IsAbsent(), null)
+	}
+}
+
+func TestNullableJSONOverwrite(t *testing.T) {
+	// Decoding into a populated field replaces it, and decoding null doesn't
+	// leave Absent.
+	deserialized := patchRequest{Name: Present("foo")}
+	err := json.Unmarshal([]byte(`{"name":null}`), &deserialized)
+	assert.NoError(t, err)
+	assert.True(t, deserialized.Name.IsNull())
+
+	err = json.Unmarshal([]byte(`{"name":"bar"}`), &deserialized)
+	assert.NoError(t, err)
+	assert.Equal(t, Present("bar"), deserialized.Name)
+
+	err = json.Unmarshal([]byte(`{"name":42}`), &deserialized)
+	assert.Error(t, err)
+}
+
+func TestNullableComparable(t *testing.T) {
+	assert.True(t, Present(42) == Present(42))
+	assert.True(t, Null[int]() == Null[int]())
+	assert.False(t, Null[int]() == Absent[int]())
+	assert.False(t, Present(0) == Null[int]())
+
+	var sink Nullable[int]
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { sink = Present(42) }))
+	_ = sink
+}
+
+func TestNullableOptionalConversion(t *testing.T) {
+	assert.Equal(t, None[int](), Absent[int]().ToOptional())
+	assert.Equal(t, None[int](), Null[int]().ToOptional())
+	assert.Equal(t, Some(42), Present(42).ToOptional())
+
+	assert.Equal(t, Null[int](), FromOptional(None[int]()))
+	assert.Equal(t, Present(42), FromOptional(Some(42)))
+
+	assert.True(t, Absent[int]().IsAbsent())
+	assert.True(t, Null[int]().IsNull())
+	assert.True(t, Present(42).IsPresent())
+	assert.False(t, Null[int]().IsPresent())
+	assert.False(t, Present(42).IsNull())
+}
@@ -77,3 +77,21 @@ func TestSomeYAML(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, someV, deserialized)
 }
+
+// An Optional is comparable whatever its type parameter, so it can be a map
+// key, and a type can contain an Optional of itself.
+type optionalNode struct {
+	next Optional[optionalNode]
+}
+
+func TestOptionalComparable(t *testing.T) {
+	none := None[[]int]()
+	assert.True(t, none == None[[]int]())
+
+	keys := map[Optional[map[string]int]]bool{None[map[string]int](): true}
+	assert.True(t, keys[None[map[string]int]()])
+
+	list := optionalNode{next: Some(optionalNode{})}
+	assert.True(t, list.next.IsSome())
+	assert.True(t, list.next.GetOrDefault(list).next.IsNone())
+}
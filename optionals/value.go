@@ -0,0 +1,126 @@
+package optionals
+
+import "encoding/json"
+
+// A Value[T] is an option type like Optional[T], but holds its value inline
+// with a presence flag instead of behind a pointer. Constructing a Value does
+// not allocate, which matters on hot paths that produce an option per element.
+//
+// Unlike Optional[T], a Value[T] is only comparable with == if T is, and a
+// type cannot contain a Value of itself. Convert between the two with
+// ToValue and Value.ToOptional.
+//
+// The JSON and YAML serialization/deserialization of a Value[T] are compatible
+// with that of a *T.
+type Value[T any] struct {
+	value  T
+	exists bool
+}
+
+func SomeValue[T any](t T) Value[T] {
+	return Value[T]{
+		value:  t,
+		exists: true,
+	}
+}
+
+func NoneValue[T any]() Value[T] {
+	return Value[T]{}
+}
+
+// Converts an Optional to a Value without allocating.
+func ToValue[T any](opt Optional[T]) Value[T] {
+	if opt.IsNone() {
+		return NoneValue[T]()
+	}
+	return SomeValue(*opt.value)
+}
+
+func (v Value[T]) IsSome() bool {
+	return v.exists
+}
+
+func (v Value[T]) IsNone() bool {
+	return !v.exists
+}
+
+func (v Value[T]) Get() (T, bool) {
+	return v.value, v.exists
+}
+
+// Returns the value inhabiting this option. If this is None, then returns the
+// given default value.
+func (v Value[T]) GetOrDefault(defaultValue T) T {
+	if v.IsNone() {
+		return defaultValue
+	}
+	return v.value
+}
+
+// Returns the value inhabiting this option. If this is None, then returns the
+// result of calling the supplied function.
+func (v Value[T]) GetOrCompute(computeValue func() (T, error)) (T, error) {
+	if v.IsNone() {
+		return computeValue()
+	}
+	return v.value, nil
+}
+
+// A version of GetOrCompute that is guaranteed to not error.
+func (v Value[T]) GetOrComputeNoError(computeValue func() T) T {
+	if v.IsNone() {
+		return computeValue()
+	}
+	return v.value
+}
+
+// Converts to a *T. If the Value is Some, its value is copied.
+func (v Value[T]) ToPtr() *T {
+	if v.IsNone() {
+		return nil
+	}
+	val := v.value
+	return &val
+}
+
+// Converts to an Optional. If the Value is Some, its value is copied to the
+// heap.
+func (v Value[T]) ToOptional() Optional[T] {
+	return ToOptional(v.ToPtr())
+}
+
+func ValueFromPtr[T any](ptr *T) Value[T] {
+	if ptr != nil {
+		return SomeValue(*ptr)
+	}
+	return NoneValue[T]()
+}
+
+func (v Value[T]) MarshalJSON() ([]byte, error) {
+	if v.IsNone() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.value)
+}
+
+func (v *Value[T]) UnmarshalJSON(data []byte) error {
+	var ptr *T
+	if err := json.Unmarshal(data, &ptr); err != nil {
+		return err
+	}
+	*v = ValueFromPtr(ptr)
+	return nil
+}
+
+func (v Value[T]) MarshalYAML() (interface{}, error) {
+	return v.ToPtr(), nil
+}
+
+func (v *Value[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ptr *T
+	if err := unmarshal(&ptr); err != nil {
+		return err
+	}
+	*v = ValueFromPtr(ptr)
+	return nil
+}
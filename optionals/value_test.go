@@ -0,0 +1,101 @@
+package optionals
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestValue(t *testing.T) {
+	some := SomeValue(42)
+	none := NoneValue[int]()
+
+	assert.True(t, some.IsSome())
+	assert.True(t, none.IsNone())
+
+	v, exists := some.Get()
+	assert.True(t, exists)
+	assert.Equal(t, 42, v)
+	_, exists = none.Get()
+	assert.False(t, exists)
+
+	assert.Equal(t, 42, some.GetOrDefault(7))
+	assert.Equal(t, 7, none.GetOrDefault(7))
+	assert.Equal(t, 7, none.GetOrComputeNoError(func() int { return 7 }))
+
+	// Values are comparable when T is.
+	assert.True(t, some == SomeValue(42))
+	assert.True(t, none == NoneValue[int]())
+	assert.False(t, some == none)
+
+	// Conversions.
+	assert.Equal(t, Some(42), some.ToOptional())
+	assert.Equal(t, None[int](), none.ToOptional())
+	assert.Equal(t, some, ToValue(Some(42)))
+	assert.Equal(t, none, ToValue(None[int]()))
+	assert.Nil(t, none.ToPtr())
+	assert.Equal(t, 42, *some.ToPtr())
+	assert.Equal(t, none, ValueFromPtr[int](nil))
+}
+
+func TestValueSerialization(t *testing.T) {
+	v := 42
+	for _, tc := range []struct {
+		name  string
+		value Value[int]
+		ptr   *int
+	}{
+		{"none", NoneValue[int](), nil},
+		{"some", SomeValue(42), &v},
+	} {
+		// Serializes the same as a *T.
+		valueJSON, err := json.Marshal(tc.value)
+		assert.NoError(t, err, tc.name)
+		ptrJSON, err := json.Marshal(tc.ptr)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, ptrJSON, valueJSON, tc.name)
+
+		valueYAML, err := yaml.Marshal(tc.value)
+		assert.NoError(t, err, tc.name)
+		ptrYAML, err := yaml.Marshal(tc.ptr)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, ptrYAML, valueYAML, tc.name)
+
+		// Round trips.
+		var fromJSON, fromYAML Value[int]
+		assert.NoError(t, json.Unmarshal(valueJSON, &fromJSON), tc.name)
+		assert.Equal(t, tc.value, fromJSON, tc.name)
+		assert.NoError(t, yaml.Unmarshal(valueYAML, &fromYAML), tc.name)
+		assert.Equal(t, tc.value, fromYAML, tc.name)
+	}
+}
+
+var sinkValue Value[[64]byte]
+var sinkOptional Optional[[64]byte]
+
+func TestValueAllocations(t *testing.T) {
+	var x [64]byte
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { sinkValue = SomeValue(x) }))
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { sinkValue = ToValue(sinkOptional) }))
+
+	// For comparison, an Optional holds its value on the heap.
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { sinkOptional = Some(x) }))
+}
+
+func BenchmarkSome(b *testing.B) {
+	var x [64]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkOptional = Some(x)
+	}
+}
+
+func BenchmarkSomeValue(b *testing.B) {
+	var x [64]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkValue = SomeValue(x)
+	}
+}
@@ -0,0 +1,222 @@
+package persistent
+
+import "math/bits"
+
+// Each level of the trie consumes this many bits of a key's hash.
+const bitsPerLevel = 5
+
+const branchMask = 1<<bitsPerLevel - 1
+
+// Hashes are 64 bits, so keys whose hashes are identical are stored together in
+// a collision node once the shift reaches this value.
+const maxShift = 64
+
+type hamtLeaf[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// Exactly one of leaf and child is non-nil.
+type hamtSlot[K comparable, V any] struct {
+	leaf  *hamtLeaf[K, V]
+	child *hamtNode[K, V]
+}
+
+// A node in a hash array mapped trie. Nodes are never modified once they are
+// reachable from a Map; updates copy the path from the root to the changed
+// node and share everything else.
+type hamtNode[K comparable, V any] struct {
+	// Bit i is set if the slot for hash fragment i is occupied.
+	bitmap uint32
+
+	// The occupied slots, in order of hash fragment.
+	slots []hamtSlot[K, V]
+
+	// Used instead of bitmap and slots in nodes at maxShift, which hold leaves
+	// whose hashes are identical.
+	collisions []*hamtLeaf[K, V]
+}
+
+func fragment(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & branchMask)
+}
+
+// Returns the index into slots for the given fragment bit.
+func (n *hamtNode[K, V]) position(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, V]) get(hash uint64, key K, shift uint) (*hamtLeaf[K, V], bool) {
+	for n != nil {
+		if shift >= maxShift {
+			for _, leaf := range n.collisions {
+				if leaf.key == key {
+					return leaf, true
+				}
+			}
+			return nil, false
+		}
+
+		bit := fragment(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+
+		slot := n.slots[n.position(bit)]
+		if slot.leaf != nil {
+			if slot.leaf.hash == hash && slot.leaf.key == key {
+				return slot.leaf, true
+			}
+			return nil, false
+		}
+
+		n = slot.child
+		shift += bitsPerLevel
+	}
+	return nil, false
+}
+
+// Returns a copy of n with the given leaf inserted, replacing any existing leaf
+// with the same key. The boolean result is true if the key was not already
+// present. n may be nil.
+func (n *hamtNode[K, V]) put(leaf *hamtLeaf[K, V], shift uint) (*hamtNode[K, V], bool) {
+	if n == nil {
+		n = &hamtNode[K, V]{}
+	}
+
+	if shift >= maxShift {
+		for i, existing := range n.collisions {
+			if existing.key == leaf.key {
+				collisions := append([]*hamtLeaf[K, V](nil), n.collisions...)
+				collisions[i] = leaf
+				return &hamtNode[K, V]{collisions: collisions}, false
+			}
+		}
+		collisions := make([]*hamtLeaf[K, V], len(n.collisions), len(n.collisions)+1)
+		copy(collisions, n.collisions)
+		return &hamtNode[K, V]{collisions: append(collisions, leaf)}, true
+	}
+
+	bit := fragment(leaf.hash, shift)
+	pos := n.position(bit)
+
+	if n.bitmap&bit == 0 {
+		slots := make([]hamtSlot[K, V], len(n.slots)+1)
+		copy(slots, n.slots[:pos])
+		slots[pos] = hamtSlot[K, V]{leaf: leaf}
+		copy(slots[pos+1:], n.slots[pos:])
+		return &hamtNode[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	var newSlot hamtSlot[K, V]
+	added := true
+	switch slot := n.slots[pos]; {
+	case slot.child != nil:
+		newSlot.child, added = slot.child.put(leaf, shift+bitsPerLevel)
+	case slot.leaf.hash == leaf.hash && slot.leaf.key == leaf.key:
+		newSlot.leaf = leaf
+		added = false
+	default:
+		// Push both leaves down into a new child.
+		child, _ := (*hamtNode[K, V])(nil).put(slot.leaf, shift+bitsPerLevel)
+		newSlot.child, _ = child.put(leaf, shift+bitsPerLevel)
+	}
+
+	return n.withSlot(pos, newSlot), added
+}
+
+// Returns a copy of n with the given key removed, or nil if the result would
+// be empty. The boolean result is true if the key was present.
+func (n *hamtNode[K, V]) delete(hash uint64, key K, shift uint) (*hamtNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if shift >= maxShift {
+		for i, existing := range n.collisions {
+			if existing.key == key {
+				if len(n.collisions) == 1 {
+					return nil, true
+				}
+				collisions := make([]*hamtLeaf[K, V], 0, len(n.collisions)-1)
+				collisions = append(collisions, n.collisions[:i]...)
+				collisions = append(collisions, n.collisions[i+1:]...)
+				return &hamtNode[K, V]{collisions: collisions}, true
+			}
+		}
+		return n, false
+	}
+
+	bit := fragment(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	pos := n.position(bit)
+	slot := n.slots[pos]
+	if slot.leaf != nil {
+		if slot.leaf.hash != hash || slot.leaf.key != key {
+			return n, false
+		}
+		return n.withoutSlot(pos, bit), true
+	}
+
+	child, removed := slot.child.delete(hash, key, shift+bitsPerLevel)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return n.withoutSlot(pos, bit), true
+	}
+
+	// Pull a lone leaf up so that the trie stays as shallow as possible.
+	if leaf := child.loneLeaf(); leaf != nil {
+		return n.withSlot(pos, hamtSlot[K, V]{leaf: leaf}), true
+	}
+	return n.withSlot(pos, hamtSlot[K, V]{child: child}), true
+}
+
+// Returns the only leaf in n if n holds a single leaf and no children.
+func (n *hamtNode[K, V]) loneLeaf() *hamtLeaf[K, V] {
+	if len(n.collisions) == 1 {
+		return n.collisions[0]
+	}
+	if len(n.slots) == 1 && n.slots[0].leaf != nil {
+		return n.slots[0].leaf
+	}
+	return nil
+}
+
+func (n *hamtNode[K, V]) withSlot(pos int, slot hamtSlot[K, V]) *hamtNode[K, V] {
+	slots := make([]hamtSlot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	slots[pos] = slot
+	return &hamtNode[K, V]{bitmap: n.bitmap, slots: slots}
+}
+
+func (n *hamtNode[K, V]) withoutSlot(pos int, bit uint32) *hamtNode[K, V] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	slots := make([]hamtSlot[K, V], 0, len(n.slots)-1)
+	slots = append(slots, n.slots[:pos]...)
+	slots = append(slots, n.slots[pos+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, slots: slots}
+}
+
+func (n *hamtNode[K, V]) forEach(f func(*hamtLeaf[K, V])) {
+	if n == nil {
+		return
+	}
+	for _, leaf := range n.collisions {
+		f(leaf)
+	}
+	for _, slot := range n.slots {
+		if slot.leaf != nil {
+			f(slot.leaf)
+		} else {
+			slot.child.forEach(f)
+		}
+	}
+}
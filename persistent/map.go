@@ -0,0 +1,168 @@
+package persistent
+
+import (
+	"encoding/json"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+// An immutable map, implemented as a hash array mapped trie. Updates return a
+// new version of the map in O(log n) time, sharing structure with the original,
+// which is left unchanged. Maps are therefore safe to share between goroutines
+// without copying.
+//
+// The zero value is an empty map.
+//
+// The JSON serialization/deserialization of a Map[K, V] is compatible with that
+// of a maps.Map[K, V].
+type Map[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	size int
+
+	// If nil, hashing.Hash is used.
+	hasher func(K) uint64
+}
+
+// Returns an empty map. Keys of any comparable type are supported, but keys
+// that are not integers or strings are hashed using reflection; use
+// NewMapWithHasher for better performance with such keys.
+func NewMap[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// Returns an empty map that hashes keys with the given function. Keys that are
+// equal must have equal hashes.
+func NewMapWithHasher[K comparable, V any](hasher func(K) uint64) Map[K, V] {
+	return Map[K, V]{hasher: hasher}
+}
+
+// Returns a persistent copy of the given map.
+func FromMap[K comparable, V any](m maps.Map[K, V]) Map[K, V] {
+	rv := NewMap[K, V]()
+	for k, v := range m {
+		rv = rv.Put(k, v)
+	}
+	return rv
+}
+
+func (m Map[K, V]) hash(k K) uint64 {
+	if m.hasher == nil {
+		return hashing.Hash(k)
+	}
+	return m.hasher(k)
+}
+
+// Returns a new map in which k is associated with v.
+func (m Map[K, V]) Put(k K, v V) Map[K, V] {
+	root, added := m.root.put(&hamtLeaf[K, V]{hash: m.hash(k), key: k, value: v}, 0)
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Returns a new map in which k is associated with v. If k is already in the
+// map, it is instead associated with the result of onConflict.
+func (m Map[K, V]) Upsert(k K, v V, onConflict func(v, newV V) V) Map[K, V] {
+	if oldV, exists := m.Get(k).Get(); exists {
+		v = onConflict(oldV, v)
+	}
+	return m.Put(k, v)
+}
+
+// Returns a new map containing the entries of both m and other. Keys present in
+// both are associated with the result of onConflict.
+func (m Map[K, V]) Add(other Map[K, V], onConflict func(v, newV V) V) Map[K, V] {
+	other.ForEach(func(k K, v V) {
+		m = m.Upsert(k, v, onConflict)
+	})
+	return m
+}
+
+// Returns a new map without the key k.
+func (m Map[K, V]) Delete(k K) Map[K, V] {
+	root, removed := m.root.delete(m.hash(k), k, 0)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+func (m Map[K, V]) Get(k K) optionals.Optional[V] {
+	if leaf, exists := m.root.get(m.hash(k), k, 0); exists {
+		return optionals.Some(leaf.value)
+	}
+	return optionals.None[V]()
+}
+
+// Returns the value associated with the given key k. If the key does not exist
+// in the map, the default Go value is returned.
+func (m Map[K, V]) GetOrDefault(k K) V {
+	var defaultValue V
+	return m.Get(k).GetOrDefault(defaultValue)
+}
+
+func (m Map[K, V]) ContainsKey(k K) bool {
+	return m.Get(k).IsSome()
+}
+
+func (m Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+func (m Map[K, V]) Size() int {
+	return m.size
+}
+
+// Calls f on each entry in the map, in a nondeterministic order.
+func (m Map[K, V]) ForEach(f func(K, V)) {
+	m.root.forEach(func(leaf *hamtLeaf[K, V]) {
+		f(leaf.key, leaf.value)
+	})
+}
+
+func (m Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.ForEach(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+func (m Map[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.ForEach(func(_ K, v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// Returns a mutable copy of the map.
+func (m Map[K, V]) ToMap() maps.Map[K, V] {
+	rv := make(maps.Map[K, V], m.size)
+	m.ForEach(rv.Put)
+	return rv
+}
+
+func (m Map[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.ToMap())
+}
+
+func (m *Map[K, V]) UnmarshalJSON(text []byte) error {
+	var entries maps.Map[K, V]
+	if err := json.Unmarshal(text, &entries); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal persistent map")
+	}
+
+	rv := NewMapWithHasher[K, V](m.hasher)
+	for k, v := range entries {
+		rv = rv.Put(k, v)
+	}
+	*m = rv
+	return nil
+}
@@ -0,0 +1,105 @@
+package persistent
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapBasicOperations(t *testing.T) {
+	m0 := NewMap[string, int]()
+	m1 := m0.Put("foo", 1)
+	m2 := m1.Upsert("foo", 2, func(v, newV int) int { return v + newV })
+	m3 := m2.Put("bar", 3).Delete("foo")
+
+	// Earlier versions are unaffected by later updates.
+	assert.True(t, m0.IsEmpty())
+	assert.Equal(t, optionals.Some(1), m1.Get("foo"))
+	assert.Equal(t, optionals.Some(3), m2.Get("foo"))
+	assert.Equal(t, maps.Map[string, int]{"bar": 3}, m3.ToMap())
+
+	assert.False(t, m3.ContainsKey("foo"))
+	assert.Equal(t, 0, m3.GetOrDefault("foo"))
+	assert.Equal(t, 1, m3.Size())
+}
+
+func TestMapModel(t *testing.T) {
+	for _, hasher := range []func(int) uint64{
+		nil,
+
+		// Forces every key to collide.
+		func(int) uint64 { return 42 },
+
+		// Forces keys to share long hash prefixes.
+		func(k int) uint64 { return uint64(k % 7) },
+	} {
+		for _, seed := range []int64{1, 2, 3} {
+			rng := rand.New(rand.NewSource(seed))
+			m := NewMapWithHasher[int, int](hasher)
+			model := map[int]int{}
+
+			for i := 0; i < 2000; i++ {
+				k := rng.Intn(300)
+				if rng.Intn(3) == 0 {
+					m = m.Delete(k)
+					delete(model, k)
+				} else {
+					m = m.Put(k, i)
+					model[k] = i
+				}
+				if !assert.Equal(t, len(model), m.Size(), fmt.Sprintf("seed %d, op %d", seed, i)) {
+					return
+				}
+			}
+
+			assert.Equal(t, maps.Map[int, int](model), m.ToMap(), fmt.Sprintf("seed %d", seed))
+			for k := 0; k < 300; k++ {
+				_, exists := model[k]
+				assert.Equal(t, exists, m.ContainsKey(k), fmt.Sprintf("seed %d: key %d", seed, k))
+			}
+		}
+	}
+}
+
+func TestMapStructKeys(t *testing.T) {
+	type key struct {
+		Name string
+		ID   int
+		Ptr  *int
+	}
+
+	x := 1
+	m := NewMap[key, string]().
+		Put(key{Name: "a", ID: 1}, "a").
+		Put(key{Name: "a", ID: 1, Ptr: &x}, "b")
+
+	assert.Equal(t, optionals.Some("a"), m.Get(key{Name: "a", ID: 1}))
+	assert.Equal(t, optionals.Some("b"), m.Get(key{Name: "a", ID: 1, Ptr: &x}))
+	assert.Equal(t, optionals.None[string](), m.Get(key{Name: "a", ID: 2}))
+
+	// Pointer keys hash by identity, so mutating the pointee is harmless.
+	ptrMap := NewMap[*int, int]().Put(&x, 1)
+	x = 2
+	assert.Equal(t, optionals.Some(1), ptrMap.Get(&x))
+}
+
+func TestMapJSON(t *testing.T) {
+	m := FromMap(maps.Map[string, int]{"foo": 1, "bar": 2})
+
+	bs, err := json.Marshal(m)
+	assert.NoError(t, err)
+
+	expected, err := json.Marshal(map[string]int{"foo": 1, "bar": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(bs))
+
+	var deserialized Map[string, int]
+	err = json.Unmarshal(bs, &deserialized)
+	assert.NoError(t, err)
+	assert.Equal(t, m.ToMap(), deserialized.ToMap(), "m == unmarshal(marshal(m))")
+}
@@ -0,0 +1,140 @@
+package persistent
+
+import (
+	"encoding/json"
+
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/pkg/errors"
+)
+
+// An immutable set. Updates return a new version of the set in O(log n) time,
+// sharing structure with the original, which is left unchanged.
+//
+// The zero value is an empty set.
+//
+// The JSON serialization/deserialization of a Set[T] is compatible with that of
+// a sets.Set[T].
+type Set[T comparable] struct {
+	m Map[T, struct{}]
+}
+
+func NewSet[T comparable](vs ...T) Set[T] {
+	return Set[T]{}.Insert(vs...)
+}
+
+// Returns an empty set that hashes elements with the given function. Elements
+// that are equal must have equal hashes.
+func NewSetWithHasher[T comparable](hasher func(T) uint64) Set[T] {
+	return Set[T]{m: NewMapWithHasher[T, struct{}](hasher)}
+}
+
+// Returns a persistent copy of the given set.
+func FromSet[T comparable](s sets.Set[T]) Set[T] {
+	rv := NewSet[T]()
+	for v := range s {
+		rv = rv.Insert(v)
+	}
+	return rv
+}
+
+// Returns a new set that also contains the given elements.
+func (s Set[T]) Insert(vs ...T) Set[T] {
+	for _, v := range vs {
+		s.m = s.m.Put(v, struct{}{})
+	}
+	return s
+}
+
+// Returns a new set without the given elements.
+func (s Set[T]) Delete(vs ...T) Set[T] {
+	for _, v := range vs {
+		s.m = s.m.Delete(v)
+	}
+	return s
+}
+
+// Returns a new set containing the elements of both s and other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	// Insert the elements of the smaller set into the larger.
+	if s.Size() < other.Size() {
+		s, other = other, s
+	}
+	other.ForEach(func(v T) {
+		s = s.Insert(v)
+	})
+	return s
+}
+
+// Returns a new set containing the elements that are in both s and other.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	s.ForEach(func(v T) {
+		if !other.Contains(v) {
+			s = s.Delete(v)
+		}
+	})
+	return s
+}
+
+func (s Set[T]) Contains(v T) bool {
+	return s.m.ContainsKey(v)
+}
+
+func (s Set[T]) ContainsAny(vs ...T) bool {
+	for _, v := range vs {
+		if s.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Set[T]) ContainsAll(vs ...T) bool {
+	for _, v := range vs {
+		if !s.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set[T]) Equals(other Set[T]) bool {
+	return s.Size() == other.Size() && s.ContainsAll(other.AsSlice()...)
+}
+
+func (s Set[T]) IsEmpty() bool {
+	return s.m.IsEmpty()
+}
+
+func (s Set[T]) Size() int {
+	return s.m.Size()
+}
+
+// Calls f on each element of the set, in a nondeterministic order.
+func (s Set[T]) ForEach(f func(T)) {
+	s.m.ForEach(func(v T, _ struct{}) {
+		f(v)
+	})
+}
+
+// AsSlice returns the set as a slice in a nondeterministic order.
+func (s Set[T]) AsSlice() []T {
+	return s.m.Keys()
+}
+
+// Returns a mutable copy of the set.
+func (s Set[T]) ToSet() sets.Set[T] {
+	return sets.NewSet(s.AsSlice()...)
+}
+
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.AsSlice())
+}
+
+func (s *Set[T]) UnmarshalJSON(text []byte) error {
+	var slice []T
+	if err := json.Unmarshal(text, &slice); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal persistent set")
+	}
+	*s = NewSetWithHasher(s.m.hasher).Insert(slice...)
+	return nil
+}
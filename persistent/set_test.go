@@ -0,0 +1,45 @@
+package persistent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBasicOperations(t *testing.T) {
+	s0 := NewSet[int]()
+	s1 := s0.Insert(1, 2)
+	s2 := s1.Union(NewSet(2, 3))
+	s3 := s2.Intersect(NewSet(1, 3, 4))
+	s4 := s3.Delete(1)
+
+	assert.True(t, s0.IsEmpty())
+	assert.Equal(t, sets.NewSet(1, 2), s1.ToSet())
+	assert.Equal(t, sets.NewSet(1, 2, 3), s2.ToSet())
+	assert.Equal(t, sets.NewSet(1, 3), s3.ToSet())
+	assert.Equal(t, sets.NewSet(3), s4.ToSet())
+
+	assert.True(t, s2.ContainsAll(1, 2, 3))
+	assert.False(t, s4.ContainsAny(1, 2))
+	assert.True(t, s3.Equals(FromSet(sets.NewSet(3, 1))))
+}
+
+func TestSetJSON(t *testing.T) {
+	s := NewSet(3, 2, 1)
+
+	bs, err := json.Marshal(s)
+	assert.NoError(t, err)
+
+	// Compatible with sets.Set.
+	var mutable sets.Set[int]
+	err = json.Unmarshal(bs, &mutable)
+	assert.NoError(t, err)
+	assert.Equal(t, sets.NewSet(1, 2, 3), mutable)
+
+	var deserialized Set[int]
+	err = json.Unmarshal(bs, &deserialized)
+	assert.NoError(t, err)
+	assert.True(t, s.Equals(deserialized), "s == unmarshal(marshal(s))")
+}
@@ -0,0 +1,280 @@
+package persistent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+const vectorBranching = 1 << bitsPerLevel
+
+// A node in a vector trie. Leaves, at level 0, hold values; internal nodes hold
+// children.
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	values   []T
+}
+
+// An immutable sequence, implemented as a bit-partitioned vector trie with a
+// tail buffer, as in Clojure. Get and Set take O(log n) time, and Append and
+// DropLast take amortized O(1) time. Updates return a new version of the
+// vector, sharing structure with the original, which is left unchanged.
+//
+// This is not an RRB (relaxed radix balanced) tree: every node but the last at
+// each level is full, so vectors can't be concatenated or sliced without
+// copying, and Concat and Slice operations are not provided.
+//
+// The zero value is an empty vector.
+//
+// The JSON serialization/deserialization of a Vector[T] is compatible with that
+// of a []T.
+type Vector[T any] struct {
+	size int
+
+	// The number of index bits consumed above the leaves. Always a positive
+	// multiple of bitsPerLevel when root is non-nil.
+	shift uint
+
+	// Holds every element except those in tail. Nil if there are no such
+	// elements.
+	root *vectorNode[T]
+
+	// The last 1 to vectorBranching elements, held outside the trie so that
+	// appends are cheap. Never modified in place once shared.
+	tail []T
+}
+
+// Returns a vector of the given elements. To copy a slice, use NewVector(s...).
+func NewVector[T any](vs ...T) Vector[T] {
+	var rv Vector[T]
+	for _, v := range vs {
+		rv = rv.Append(v)
+	}
+	return rv
+}
+
+func (vec Vector[T]) IsEmpty() bool {
+	return vec.size == 0
+}
+
+func (vec Vector[T]) Size() int {
+	return vec.size
+}
+
+// Returns the element at index i, or None if i is out of range.
+func (vec Vector[T]) Get(i int) optionals.Optional[T] {
+	if i < 0 || i >= vec.size {
+		return optionals.None[T]()
+	}
+	return optionals.Some(vec.leafFor(i)[i&branchMask])
+}
+
+// Returns the last element, or None if the vector is empty.
+func (vec Vector[T]) Last() optionals.Optional[T] {
+	return vec.Get(vec.size - 1)
+}
+
+// Returns a new vector with v added to the end.
+func (vec Vector[T]) Append(v T) Vector[T] {
+	if len(vec.tail) < vectorBranching {
+		tail := make([]T, len(vec.tail), len(vec.tail)+1)
+		copy(tail, vec.tail)
+		vec.tail = append(tail, v)
+		vec.size++
+		return vec
+	}
+
+	// The tail is full. Move it into the trie and start a new one.
+	tailNode := &vectorNode[T]{values: vec.tail}
+	switch {
+	case vec.root == nil:
+		vec.root = &vectorNode[T]{children: []*vectorNode[T]{tailNode}}
+		vec.shift = bitsPerLevel
+	case (vec.size >> bitsPerLevel) > (1 << vec.shift):
+		// The trie is full. Add a level.
+		vec.root = &vectorNode[T]{
+			children: []*vectorNode[T]{vec.root, newVectorPath(vec.shift, tailNode)},
+		}
+		vec.shift += bitsPerLevel
+	default:
+		vec.root = vec.pushTail(vec.shift, vec.root, tailNode)
+	}
+
+	vec.tail = []T{v}
+	vec.size++
+	return vec
+}
+
+// Returns a new vector in which the element at index i is v. Panics if i is out
+// of range.
+func (vec Vector[T]) Set(i int, v T) Vector[T] {
+	if i < 0 || i >= vec.size {
+		panic(fmt.Sprintf("persistent: index %d out of range [0:%d]", i, vec.size))
+	}
+
+	if i >= vec.tailOffset() {
+		tail := make([]T, len(vec.tail))
+		copy(tail, vec.tail)
+		tail[i&branchMask] = v
+		vec.tail = tail
+		return vec
+	}
+
+	vec.root = setInNode(vec.shift, vec.root, i, v)
+	return vec
+}
+
+// Returns a new vector without its last element. Returns the vector unchanged
+// if it is empty.
+func (vec Vector[T]) DropLast() Vector[T] {
+	switch {
+	case vec.size == 0:
+		return vec
+	case vec.size == 1:
+		return Vector[T]{}
+	case len(vec.tail) > 1:
+		// Reslicing is safe, since the tail is copied before it is extended.
+		vec.tail = vec.tail[:len(vec.tail)-1]
+		vec.size--
+		return vec
+	}
+
+	// The tail is about to become empty. Pull the last leaf out of the trie to
+	// serve as the new tail.
+	vec.tail = vec.leafFor(vec.size - 2)
+	vec.root = vec.popTail(vec.shift, vec.root)
+	vec.size--
+
+	if vec.root == nil {
+		vec.shift = 0
+	} else if vec.shift > bitsPerLevel && len(vec.root.children) == 1 {
+		vec.root = vec.root.children[0]
+		vec.shift -= bitsPerLevel
+	}
+	return vec
+}
+
+// Calls f on each element, in order.
+func (vec Vector[T]) ForEach(f func(T)) {
+	for i := 0; i < vec.size; i += vectorBranching {
+		for _, v := range vec.leafFor(i) {
+			f(v)
+		}
+	}
+}
+
+// Returns the elements as a new slice.
+func (vec Vector[T]) AsSlice() []T {
+	rv := make([]T, 0, vec.size)
+	vec.ForEach(func(v T) {
+		rv = append(rv, v)
+	})
+	return rv
+}
+
+func (vec Vector[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vec.AsSlice())
+}
+
+func (vec *Vector[T]) UnmarshalJSON(text []byte) error {
+	var slice []T
+	if err := json.Unmarshal(text, &slice); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal persistent vector")
+	}
+	*vec = NewVector(slice...)
+	return nil
+}
+
+// Returns the index of the first element in the tail.
+func (vec Vector[T]) tailOffset() int {
+	return vec.size - len(vec.tail)
+}
+
+// Returns the leaf values containing the element at index i.
+func (vec Vector[T]) leafFor(i int) []T {
+	if i >= vec.tailOffset() {
+		return vec.tail
+	}
+
+	node := vec.root
+	for level := vec.shift; level > 0; level -= bitsPerLevel {
+		node = node.children[(i>>level)&branchMask]
+	}
+	return node.values
+}
+
+// Returns a copy of node with tailNode inserted as the rightmost leaf.
+func (vec Vector[T]) pushTail(level uint, node, tailNode *vectorNode[T]) *vectorNode[T] {
+	idx := ((vec.size - 1) >> level) & branchMask
+
+	var child *vectorNode[T]
+	switch {
+	case level == bitsPerLevel:
+		child = tailNode
+	case idx < len(node.children):
+		child = vec.pushTail(level-bitsPerLevel, node.children[idx], tailNode)
+	default:
+		child = newVectorPath(level-bitsPerLevel, tailNode)
+	}
+
+	children := make([]*vectorNode[T], len(node.children), len(node.children)+1)
+	copy(children, node.children)
+	if idx < len(children) {
+		children[idx] = child
+	} else {
+		children = append(children, child)
+	}
+	return &vectorNode[T]{children: children}
+}
+
+// Returns a copy of node with its rightmost leaf removed, or nil if the result
+// would be empty.
+func (vec Vector[T]) popTail(level uint, node *vectorNode[T]) *vectorNode[T] {
+	idx := ((vec.size - 2) >> level) & branchMask
+
+	if level > bitsPerLevel {
+		child := vec.popTail(level-bitsPerLevel, node.children[idx])
+		if child == nil && idx == 0 {
+			return nil
+		}
+
+		children := make([]*vectorNode[T], idx, idx+1)
+		copy(children, node.children[:idx])
+		if child != nil {
+			children = append(children, child)
+		}
+		return &vectorNode[T]{children: children}
+	}
+
+	if idx == 0 {
+		return nil
+	}
+	return &vectorNode[T]{children: node.children[:idx:idx]}
+}
+
+// Returns a chain of single-child nodes of the given height, ending in leaf.
+func newVectorPath[T any](level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode[T]{children: []*vectorNode[T]{newVectorPath(level-bitsPerLevel, leaf)}}
+}
+
+// Returns a copy of the path from node to the element at index i, with that
+// element replaced by v.
+func setInNode[T any](level uint, node *vectorNode[T], i int, v T) *vectorNode[T] {
+	if level == 0 {
+		values := make([]T, len(node.values))
+		copy(values, node.values)
+		values[i&branchMask] = v
+		return &vectorNode[T]{values: values}
+	}
+
+	idx := (i >> level) & branchMask
+	children := make([]*vectorNode[T], len(node.children))
+	copy(children, node.children)
+	children[idx] = setInNode(level-bitsPerLevel, children[idx], i, v)
+	return &vectorNode[T]{children: children}
+}
@@ -0,0 +1,98 @@
+package persistent
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorBasicOperations(t *testing.T) {
+	v0 := NewVector[int]()
+	v1 := v0.Append(1).Append(2)
+	v2 := v1.Set(0, 3)
+	v3 := v2.DropLast()
+
+	assert.True(t, v0.IsEmpty())
+	assert.Equal(t, []int{1, 2}, v1.AsSlice())
+	assert.Equal(t, []int{3, 2}, v2.AsSlice())
+	assert.Equal(t, []int{3}, v3.AsSlice())
+
+	assert.Equal(t, optionals.Some(3), v3.Get(0))
+	assert.Equal(t, optionals.None[int](), v3.Get(1))
+	assert.Equal(t, optionals.None[int](), v3.Get(-1))
+	assert.Equal(t, optionals.Some(2), v2.Last())
+	assert.True(t, v3.DropLast().IsEmpty())
+	assert.Panics(t, func() { v3.Set(1, 0) })
+}
+
+func TestVectorModel(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		rng := rand.New(rand.NewSource(seed))
+		vec := NewVector[int]()
+		model := []int{}
+
+		// Keep old versions to check that they are unaffected by later updates.
+		var versions []Vector[int]
+		var snapshots [][]int
+
+		// Bias towards appends so that the trie grows several levels deep.
+		for i := 0; i < 5000; i++ {
+			switch op := rng.Intn(10); {
+			case op < 6:
+				vec = vec.Append(i)
+				model = append(model, i)
+			case op < 8 && len(model) > 0:
+				idx := rng.Intn(len(model))
+				vec = vec.Set(idx, -i)
+				model[idx] = -i
+			default:
+				vec = vec.DropLast()
+				if len(model) > 0 {
+					model = model[:len(model)-1]
+				}
+			}
+
+			if i%500 == 0 {
+				versions = append(versions, vec)
+				snapshots = append(snapshots, append([]int{}, model...))
+			}
+		}
+
+		assert.Equal(t, len(model), vec.Size(), fmt.Sprintf("seed %d: size", seed))
+		assert.Equal(t, model, vec.AsSlice(), fmt.Sprintf("seed %d: elements", seed))
+		for i, idx := range []int{0, len(model) / 2, len(model) - 1} {
+			assert.Equal(t, optionals.Some(model[idx]), vec.Get(idx), fmt.Sprintf("seed %d: get %d", seed, i))
+		}
+		for i := range versions {
+			assert.Equal(t, snapshots[i], versions[i].AsSlice(), fmt.Sprintf("seed %d: version %d", seed, i))
+		}
+	}
+}
+
+func TestVectorDropToEmpty(t *testing.T) {
+	vec := NewVector(make([]int, 2000)...)
+	for !vec.IsEmpty() {
+		vec = vec.DropLast()
+	}
+	assert.Equal(t, []int{}, vec.AsSlice())
+
+	vec = vec.Append(1)
+	assert.Equal(t, []int{1}, vec.AsSlice())
+}
+
+func TestVectorJSON(t *testing.T) {
+	vec := NewVector(1, 2, 3)
+
+	bs, err := json.Marshal(vec)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2,3]", string(bs))
+
+	var deserialized Vector[int]
+	err = json.Unmarshal(bs, &deserialized)
+	assert.NoError(t, err)
+	assert.Equal(t, vec.AsSlice(), deserialized.AsSlice(), "v == unmarshal(marshal(v))")
+}
@@ -0,0 +1,161 @@
+// Package queuetest provides a behavioral test suite for implementations of
+// queues.Queue.
+package queuetest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/akitasoftware/go-utils/queues"
+	"github.com/stretchr/testify/assert"
+)
+
+// The number of random operations performed by each model-based check. Queues
+// under test must be able to hold at least this many elements.
+const NumRandomOps = 500
+
+// Seeds used by the model-based checks. Fixed so that failures are
+// reproducible.
+var seeds = []int64{1, 2, 3, 4, 5}
+
+// Runs a suite of behavioral tests against a Queue implementation. Each call to
+// newQueue must return a new, empty queue.
+func Run(t *testing.T, newQueue func() queues.Queue[int]) {
+	t.Run("EnqueueDequeue", func(t *testing.T) {
+		testEnqueueDequeue(t, newQueue)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		testEmpty(t, newQueue())
+	})
+	t.Run("Model", func(t *testing.T) {
+		for _, seed := range seeds {
+			testModel(t, newQueue(), seed)
+		}
+	})
+}
+
+func testEnqueueDequeue(t *testing.T, newQueue func() queues.Queue[int]) {
+	tests := []struct {
+		name  string
+		input []int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+		},
+		{
+			name:  "singleton",
+			input: []int{1},
+		},
+		{
+			name:  "list",
+			input: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range tests {
+		q := newQueue()
+		label := func(msg string) string {
+			return fmt.Sprintf("%s: %s", tc.name, msg)
+		}
+
+		assert.True(t, q.IsEmpty(), label("empty"))
+
+		for _, v := range tc.input {
+			q.Enqueue(v)
+		}
+
+		assert.Equal(t, len(tc.input), q.Size(), label("length"))
+
+		if len(tc.input) > 0 {
+			assert.False(t, q.IsEmpty(), label("not empty"))
+		}
+
+		// Test ForEach.  It should behave as if we had dequeued each
+		// element.
+		foreachOutput := make([]int, 0, q.Size())
+		q.ForEach(func(v int) {
+			foreachOutput = append(foreachOutput, v)
+		})
+		assert.Equal(t, tc.input, foreachOutput, label("foreach output"))
+
+		// Dequeue all elements, and check that the resulting list is equal
+		// to the input.
+		output := make([]int, q.Size())
+		for i := range output {
+			if v, exists := q.Dequeue().Get(); exists {
+				output[i] = v
+			}
+		}
+
+		assert.Equal(t, tc.input, output, label("output"))
+		assert.True(t, q.IsEmpty(), label("empty after dequeue"))
+	}
+}
+
+func testEmpty(t *testing.T, q queues.Queue[int]) {
+	assert.True(t, q.IsEmpty())
+	assert.Equal(t, 0, q.Size())
+	assert.Equal(t, optionals.None[int](), q.Peek())
+	assert.Equal(t, optionals.None[int](), q.Dequeue())
+
+	// Dequeuing from an empty queue must leave it usable.
+	q.Enqueue(1)
+	assert.Equal(t, optionals.Some(1), q.Peek())
+	assert.Equal(t, optionals.Some(1), q.Dequeue())
+	assert.True(t, q.IsEmpty())
+}
+
+// Performs a random sequence of operations on q, checking after each one that q
+// agrees with a reference queue modelled as a slice whose first element is the
+// front.
+func testModel(t *testing.T, q queues.Queue[int], seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	model := []int{}
+
+	front := func() optionals.Optional[int] {
+		if len(model) == 0 {
+			return optionals.None[int]()
+		}
+		return optionals.Some(model[0])
+	}
+
+	for i := 0; i < NumRandomOps; i++ {
+		label := func(msg string) string {
+			return fmt.Sprintf("seed %d, op %d: %s", seed, i, msg)
+		}
+
+		switch op := rng.Intn(4); op {
+		case 0, 1:
+			// Bias towards enqueues so that the queue grows.
+			v := rng.Int()
+			q.Enqueue(v)
+			model = append(model, v)
+		case 2:
+			expected := front()
+			if len(model) > 0 {
+				model = model[1:]
+			}
+			if !assert.Equal(t, expected, q.Dequeue(), label("dequeue")) {
+				return
+			}
+		case 3:
+			if !assert.Equal(t, front(), q.Peek(), label("peek")) {
+				return
+			}
+		}
+
+		if !assert.Equal(t, len(model), q.Size(), label("size")) {
+			return
+		}
+		assert.Equal(t, len(model) == 0, q.IsEmpty(), label("is empty"))
+	}
+
+	foreachOutput := []int{}
+	q.ForEach(func(v int) {
+		foreachOutput = append(foreachOutput, v)
+	})
+	assert.Equal(t, model, foreachOutput, fmt.Sprintf("seed %d: foreach output", seed))
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/akitasoftware/go-utils/maps"
+)
+
+// Applies a separate limiter to each key, such as one per endpoint or per
+// destination. At most maxKeys limiters are retained; when a new key would
+// exceed that, the least recently used key's limiter is discarded, so a key
+// that returns after being evicted starts with a fresh limiter.
+//
+// Safe for concurrent use.
+type KeyedLimiter[K comparable] struct {
+	mu         sync.Mutex
+	limiters   *maps.LRUMap[K, Limiter]
+	newLimiter func() Limiter
+}
+
+// Returns a keyed limiter that calls newLimiter to create the limiter for each
+// key. Panics if maxKeys is not positive.
+func NewKeyedLimiter[K comparable](maxKeys int, newLimiter func() Limiter) *KeyedLimiter[K] {
+	return &KeyedLimiter[K]{
+		limiters:   maps.NewLRUMap[K, Limiter](maxKeys),
+		newLimiter: newLimiter,
+	}
+}
+
+// Returns the limiter for the given key, creating it if necessary.
+func (l *KeyedLimiter[K]) Get(k K) Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limiters.GetOrComputeNoError(k, l.newLimiter)
+}
+
+// Reports whether an event for the given key may happen now.
+func (l *KeyedLimiter[K]) Allow(k K) bool {
+	return l.Get(k).Allow()
+}
+
+// Reserves capacity for an event for the given key.
+func (l *KeyedLimiter[K]) Reserve(k K) Reservation {
+	return l.Get(k).Reserve()
+}
+
+// Blocks until an event for the given key may happen.
+func (l *KeyedLimiter[K]) Wait(ctx context.Context, k K) error {
+	return l.Get(k).Wait(ctx)
+}
+
+// Discards the limiter for the given key.
+func (l *KeyedLimiter[K]) Delete(k K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limiters.Delete(k)
+}
+
+// Returns the number of keys with a retained limiter.
+func (l *KeyedLimiter[K]) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limiters.Size()
+}
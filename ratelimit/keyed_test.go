@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	l := NewKeyedLimiter[string](2, func() Limiter { return NewTokenBucket(1, 1, clk) })
+
+	// Keys are limited independently.
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+	assert.True(t, l.Allow("b"))
+	assert.Equal(t, 2, l.Size())
+
+	// Adding a third key evicts the least recently used, "a".
+	assert.True(t, l.Allow("c"))
+	assert.Equal(t, 2, l.Size())
+	assert.True(t, l.Allow("a"), "evicted key gets a fresh limiter")
+
+	r := l.Reserve("a")
+	assert.Equal(t, time.Second, r.Delay())
+
+	l.Delete("a")
+	assert.Equal(t, 1, l.Size())
+}
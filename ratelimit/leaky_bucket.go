@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+)
+
+// A leaky-bucket rate limiter, used as a queue. Events leak out of the bucket
+// evenly spaced at the given rate, with no bursts; up to capacity events may
+// be queued waiting for their turn, and further events are rejected. This
+// smooths out bursty input, unlike TokenBucket, which passes bursts through.
+type LeakyBucket struct {
+	mu    sync.Mutex
+	clock clock.Clock
+
+	// The time between consecutive events.
+	interval time.Duration
+	capacity int
+
+	// The earliest time at which the next event may happen.
+	next time.Time
+}
+
+var _ Limiter = (*LeakyBucket)(nil)
+
+// Returns a leaky bucket that lets through the given number of events per
+// second, queueing up to capacity events. If clk is nil, the system clock is
+// used. Panics if rate is not positive or capacity is negative. Events are
+// spaced by at least a nanosecond, so rates above 10^9 per second are treated
+// as 10^9 per second.
+func NewLeakyBucket(rate float64, capacity int, clk clock.Clock) *LeakyBucket {
+	if !(rate > 0) || capacity < 0 {
+		panic("ratelimit: rate must be positive and capacity must not be negative")
+	}
+	clk = clock.OrReal(clk)
+	return &LeakyBucket{
+		clock:    clk,
+		interval: intervalForRate(rate),
+		capacity: capacity,
+		next:     clk.Now(),
+	}
+}
+
+// Returns the number of reserved events still waiting for their turn.
+func (b *LeakyBucket) Queued() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queuedAt(b.clock.Now())
+}
+
+// Must be called with the lock held.
+func (b *LeakyBucket) queuedAt(now time.Time) int {
+	// The most recently reserved event happens one interval before next.
+	last := b.next.Add(-b.interval)
+	if !last.After(now) {
+		return 0
+	}
+	d := last.Sub(now)
+	rv := d / b.interval
+	if d%b.interval != 0 {
+		rv++
+	}
+	return int(rv)
+}
+
+// Returns the time between events at the given positive rate, clamped to a
+// positive Duration.
+func intervalForRate(rate float64) time.Duration {
+	interval := float64(time.Second) / rate
+	switch {
+	case interval < 1:
+		return 1
+	case interval >= math.MaxInt64:
+		return math.MaxInt64
+	}
+	return time.Duration(interval)
+}
+
+// Reports whether an event may happen now without waiting.
+func (b *LeakyBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if b.next.After(now) {
+		return false
+	}
+	b.next = now.Add(b.interval)
+	return true
+}
+
+// Reserves the next free slot. The reservation is not OK if the queue is
+// full.
+func (b *LeakyBucket) Reserve() Reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	start := b.next
+	if start.Before(now) {
+		start = now
+	}
+	if start.After(now) && b.queuedAt(now) >= b.capacity {
+		return Reservation{}
+	}
+
+	b.next = start.Add(b.interval)
+
+	// Shared by all copies of the reservation. Guarded by b.mu.
+	cancelled := false
+
+	return Reservation{
+		ok:    true,
+		delay: start.Sub(now),
+		cancel: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			if cancelled || b.clock.Now().After(start) {
+				return
+			}
+			cancelled = true
+
+			// Only the most recent reservation can be returned without
+			// disturbing the others.
+			if b.next.Equal(start.Add(b.interval)) {
+				b.next = start
+			}
+		},
+	}
+}
+
+func (b *LeakyBucket) Wait(ctx context.Context) error {
+	return waitForReservation(ctx, b.clock, b.Reserve())
+}
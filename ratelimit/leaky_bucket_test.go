@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeakyBucketAllow(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewLeakyBucket(10, 5, clk)
+
+	// No bursts: events are spaced by at least 100ms.
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+	clk.Advance(50 * time.Millisecond)
+	assert.False(t, b.Allow())
+	clk.Advance(50 * time.Millisecond)
+	assert.True(t, b.Allow())
+}
+
+func TestLeakyBucketReserve(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewLeakyBucket(10, 2, clk)
+
+	// The first event goes immediately, and two more may be queued.
+	for i, expected := range []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		r := b.Reserve()
+		assert.True(t, r.OK(), i)
+		assert.Equal(t, expected, r.Delay(), i)
+	}
+	assert.Equal(t, 2, b.Queued())
+	assert.False(t, b.Reserve().OK(), "queue is full")
+
+	// The queue drains as time passes.
+	clk.Advance(100 * time.Millisecond)
+	assert.Equal(t, 1, b.Queued())
+	r := b.Reserve()
+	assert.True(t, r.OK())
+	assert.Equal(t, 200*time.Millisecond, r.Delay())
+
+	// Cancelling the most recent reservation frees its slot.
+	r.Cancel()
+	assert.Equal(t, 1, b.Queued())
+}
+
+func TestLeakyBucketCancel(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewLeakyBucket(10, 2, clk)
+	b.Allow()
+
+	// A copy of a cancelled reservation doesn't cancel the reservation that
+	// took over its slot.
+	r := b.Reserve()
+	copied := r
+	r.Cancel()
+	assert.Equal(t, 0, b.Queued())
+	r2 := b.Reserve()
+	assert.Equal(t, 100*time.Millisecond, r2.Delay())
+	copied.Cancel()
+	assert.Equal(t, 1, b.Queued())
+
+	// Once the reservation's time has passed, cancelling it doesn't free a
+	// slot that was already used.
+	clk.Advance(150 * time.Millisecond)
+	r2.Cancel()
+	assert.False(t, b.Allow())
+}
+
+func TestLeakyBucketWait(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewLeakyBucket(1, 1, clk)
+	ctx := context.Background()
+
+	assert.NoError(t, b.Wait(ctx))
+	done := startWait(clk, func() error { return b.Wait(ctx) })
+	clk.Advance(time.Second)
+	assert.NoError(t, <-done)
+
+	// Without a queue, only immediate events are allowed.
+	noQueue := NewLeakyBucket(1, 0, clk)
+	assert.NoError(t, noQueue.Wait(ctx))
+	assert.Error(t, noQueue.Wait(ctx))
+}
+
+func TestLeakyBucketExtremeRates(t *testing.T) {
+	clk := clock.NewFake(testStart)
+
+	// Events are spaced by at least a nanosecond.
+	b := NewLeakyBucket(1e12, 2, clk)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+	assert.Equal(t, time.Nanosecond, b.Reserve().Delay())
+	assert.Equal(t, 1, b.Queued())
+
+	b = NewLeakyBucket(1e-12, 1, clk)
+	assert.True(t, b.Allow())
+	r := b.Reserve()
+	assert.True(t, r.OK())
+	assert.Equal(t, time.Duration(math.MaxInt64), r.Delay())
+	assert.Equal(t, 1, b.Queued())
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/pkg/errors"
+)
+
+// Controls how frequently events may happen. Implementations are safe for
+// concurrent use.
+type Limiter interface {
+	// Reports whether an event may happen now, consuming capacity if so.
+	Allow() bool
+
+	// Reserves capacity for an event, returning a Reservation that says how
+	// long the caller must wait before the event may happen. Capacity is
+	// consumed unless the reservation is cancelled.
+	Reserve() Reservation
+
+	// Blocks until an event may happen, or returns an error if the context is
+	// done first or would be done before the event may happen.
+	Wait(ctx context.Context) error
+}
+
+// The outcome of a call to Reserve.
+type Reservation struct {
+	ok     bool
+	delay  time.Duration
+	cancel func()
+}
+
+// Returns false if the limiter can never grant the reservation, for example
+// because it asks for more than the limiter's capacity. In that case, Delay is
+// meaningless and no capacity was consumed.
+func (r Reservation) OK() bool {
+	return r.ok
+}
+
+// Returns how long the caller must wait, from the time of the reservation,
+// before the event may happen.
+func (r Reservation) Delay() time.Duration {
+	return r.delay
+}
+
+// Returns the reserved capacity to the limiter, as far as possible without
+// affecting reservations made since. Cancelling has no effect once the
+// reservation's delay has passed, since the capacity is then considered used.
+// Cancelling a reservation that is not OK, or cancelling more than once,
+// including through copies of the Reservation, also has no effect.
+func (r *Reservation) Cancel() {
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// Waits out the given reservation, cancelling it if the context is done first.
+func waitForReservation(ctx context.Context, clk clock.Clock, r Reservation) error {
+	if !r.ok {
+		return errors.New("rate limit can never be satisfied")
+	}
+	if r.delay <= 0 {
+		return nil
+	}
+
+	// The deadline is on the system clock, which clk may not be, so compare
+	// the time left until it with the delay rather than comparing times.
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < r.delay {
+		r.Cancel()
+		return errors.Errorf("waiting %v for rate limit would exceed context deadline", r.delay)
+	}
+
+	select {
+	case <-clk.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
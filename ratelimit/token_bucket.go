@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+)
+
+// A token-bucket rate limiter. The bucket holds up to burst tokens and refills
+// continuously at the given rate; each event consumes one token. This allows
+// bursts of up to burst events, while limiting the long-run average to rate
+// events per second.
+type TokenBucket struct {
+	mu    sync.Mutex
+	clock clock.Clock
+
+	// Tokens per second.
+	rate  float64
+	burst float64
+
+	// The number of tokens as of last. Negative when reservations are pending.
+	tokens float64
+	last   time.Time
+}
+
+var _ Limiter = (*TokenBucket)(nil)
+
+// Returns a full token bucket that refills at the given number of tokens per
+// second, up to burst tokens. If clk is nil, the system clock is used. Panics
+// if rate or burst is not positive.
+func NewTokenBucket(rate float64, burst int, clk clock.Clock) *TokenBucket {
+	if !(rate > 0) || burst <= 0 {
+		panic("ratelimit: rate and burst must be positive")
+	}
+	clk = clock.OrReal(clk)
+	return &TokenBucket{
+		clock:  clk,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clk.Now(),
+	}
+}
+
+// Refills the bucket for the time elapsed since the last call. Must be called
+// with the lock held.
+func (b *TokenBucket) advance() time.Time {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	return now
+}
+
+// Returns the number of tokens currently available. Negative if reservations
+// are pending.
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.tokens
+}
+
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// Reports whether n events may happen now, consuming n tokens if so. Returns
+// false if n is not positive.
+func (b *TokenBucket) AllowN(n int) bool {
+	if n <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+func (b *TokenBucket) Reserve() Reservation {
+	return b.ReserveN(1)
+}
+
+// Reserves n tokens. The reservation is not OK if n is not positive or
+// exceeds the burst size.
+func (b *TokenBucket) ReserveN(n int) Reservation {
+	if n <= 0 {
+		return Reservation{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if float64(n) > b.burst {
+		return Reservation{}
+	}
+
+	now := b.advance()
+	b.tokens -= float64(n)
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+	}
+	at := now.Add(delay)
+
+	// Shared by all copies of the reservation. Guarded by b.mu.
+	cancelled := false
+
+	return Reservation{
+		ok:    true,
+		delay: delay,
+		cancel: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			now := b.advance()
+			if cancelled || now.After(at) {
+				return
+			}
+			cancelled = true
+			b.tokens = math.Min(b.burst, b.tokens+float64(n))
+		},
+	}
+}
+
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	return b.WaitN(ctx, 1)
+}
+
+// Blocks until n events may happen. Returns an error immediately if n is not
+// positive or exceeds the burst size.
+func (b *TokenBucket) WaitN(ctx context.Context, n int) error {
+	return waitForReservation(ctx, b.clock, b.ReserveN(n))
+}
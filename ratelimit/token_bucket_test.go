@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akitasoftware/go-utils/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Calls wait in a goroutine, and returns a channel that receives its result.
+// Blocks until wait is either done or blocked on the clock.
+func startWait(clk *clock.Fake, wait func() error) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- wait() }()
+
+	for clk.Waiters() == 0 && len(done) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	return done
+}
+
+func TestTokenBucketAllow(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewTokenBucket(2, 3, clk)
+
+	// The bucket starts full.
+	assert.True(t, b.Allow())
+	assert.True(t, b.AllowN(2))
+	assert.False(t, b.Allow())
+
+	// Refills at 2 tokens per second.
+	clk.Advance(500 * time.Millisecond)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	// Never exceeds the burst size.
+	clk.Advance(time.Hour)
+	assert.InDelta(t, 3, b.Tokens(), 1e-9)
+	assert.False(t, b.AllowN(4))
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewTokenBucket(10, 1, clk)
+
+	r := b.Reserve()
+	assert.True(t, r.OK())
+	assert.Equal(t, time.Duration(0), r.Delay())
+
+	r = b.Reserve()
+	assert.True(t, r.OK())
+	assert.Equal(t, 100*time.Millisecond, r.Delay())
+
+	r2 := b.Reserve()
+	assert.Equal(t, 200*time.Millisecond, r2.Delay())
+
+	// Cancelling returns the tokens.
+	r2.Cancel()
+	r2.Cancel()
+	assert.Equal(t, 200*time.Millisecond, b.Reserve().Delay())
+
+	assert.False(t, b.ReserveN(2).OK())
+}
+
+func TestTokenBucketCancel(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewTokenBucket(10, 1, clk)
+	b.Allow()
+
+	// Copies of a reservation share its cancellation, so the tokens are only
+	// returned once.
+	r := b.Reserve()
+	assert.Equal(t, 100*time.Millisecond, r.Delay())
+	copied := r
+	r.Cancel()
+	copied.Cancel()
+	assert.InDelta(t, 0, b.Tokens(), 1e-9)
+
+	// Once the reservation's delay has passed, its tokens have been used and
+	// cancelling doesn't return them.
+	r = b.Reserve()
+	clk.Advance(150 * time.Millisecond)
+	r.Cancel()
+	assert.InDelta(t, 0.5, b.Tokens(), 1e-9)
+
+	// Cancelling before the delay has passed still returns the tokens.
+	r = b.Reserve()
+	assert.Equal(t, 50*time.Millisecond, r.Delay())
+	clk.Advance(50 * time.Millisecond)
+	r.Cancel()
+	assert.InDelta(t, 1, b.Tokens(), 1e-9)
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewTokenBucket(1, 1, clk)
+	ctx := context.Background()
+
+	assert.NoError(t, b.Wait(ctx))
+
+	done := startWait(clk, func() error { return b.Wait(ctx) })
+	clk.Advance(999 * time.Millisecond)
+	assert.Len(t, done, 0)
+	clk.Advance(time.Millisecond)
+	assert.NoError(t, <-done)
+
+	// Fails immediately if the wait would exceed the deadline.
+	deadlineCtx, cancel := context.WithDeadline(ctx, time.Now().Add(500*time.Millisecond))
+	defer cancel()
+	assert.Error(t, b.Wait(deadlineCtx))
+	assert.InDelta(t, 0, b.Tokens(), 1e-9, "the failed wait's reservation is cancelled")
+
+	// Fails if the context is cancelled while waiting.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	done = startWait(clk, func() error { return b.Wait(cancelCtx) })
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	assert.Error(t, b.WaitN(ctx, 2))
+	assert.Error(t, b.WaitN(ctx, 0))
+}
+
+func TestTokenBucketWaitDeadlineWithFakeClock(t *testing.T) {
+	// The context's deadline is on the system clock, which is a day behind the
+	// fake clock. Only the time left until the deadline matters.
+	clk := clock.NewFake(time.Now().Add(24 * time.Hour))
+	b := NewTokenBucket(1, 1, clk)
+	assert.True(t, b.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	done := startWait(clk, func() error { return b.Wait(ctx) })
+	clk.Advance(time.Second)
+	assert.NoError(t, <-done)
+}
+
+func TestTokenBucketNonPositiveN(t *testing.T) {
+	clk := clock.NewFake(testStart)
+	b := NewTokenBucket(1, 5, clk)
+
+	for _, n := range []int{0, -1, -100} {
+		assert.False(t, b.AllowN(n), n)
+		assert.False(t, b.ReserveN(n).OK(), n)
+	}
+	assert.InDelta(t, 5, b.Tokens(), 1e-9)
+}
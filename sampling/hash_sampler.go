@@ -0,0 +1,39 @@
+package sampling
+
+import (
+	"math"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+)
+
+// Deterministically samples a fraction of IDs by hashing them, so that the same
+// ID is always either sampled or not. Samplers with the same rate and seed make
+// the same decisions, even across processes, for IDs that are strings, numbers,
+// or structs and arrays of these.
+type HashSampler[K comparable] struct {
+	// IDs whose salted hash is below this threshold are sampled.
+	threshold uint64
+	all       bool
+	seed      uint64
+}
+
+// Returns a sampler that selects about the given fraction of IDs. Different
+// seeds select independent subsets of IDs. Panics if rate is not in [0, 1].
+func NewHashSampler[K comparable](rate float64, seed uint64) HashSampler[K] {
+	if !(rate >= 0 && rate <= 1) {
+		panic("sampling: rate must be in [0, 1]")
+	}
+	return HashSampler[K]{
+		threshold: uint64(rate * math.MaxUint64),
+		all:       rate == 1,
+		seed:      seed,
+	}
+}
+
+// Returns true if the given ID is in the sample.
+func (s HashSampler[K]) ShouldSample(id K) bool {
+	if s.all {
+		return true
+	}
+	return hashing.Mix(hashing.Hash(id)^s.seed) < s.threshold
+}
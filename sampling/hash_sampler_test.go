@@ -0,0 +1,35 @@
+package sampling
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashSampler(t *testing.T) {
+	s := NewHashSampler[string](0.25, 0)
+	same := NewHashSampler[string](0.25, 0)
+	reseeded := NewHashSampler[string](0.25, 1)
+
+	sampled := 0
+	agreements := 0
+	for i := 0; i < 100000; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		decision := s.ShouldSample(id)
+		assert.Equal(t, decision, same.ShouldSample(id), "deterministic")
+		if decision {
+			sampled++
+		}
+		if decision == reseeded.ShouldSample(id) {
+			agreements++
+		}
+	}
+	assert.InEpsilon(t, 25000, sampled, 0.03)
+
+	// Independent samples of 25% agree on 0.25*0.25 + 0.75*0.75 of IDs.
+	assert.InEpsilon(t, 62500, agreements, 0.03)
+
+	assert.True(t, NewHashSampler[int](1, 0).ShouldSample(42))
+	assert.False(t, NewHashSampler[int](0, 0).ShouldSample(42))
+}
@@ -0,0 +1,149 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Maintains a uniform random sample of up to k elements from a stream of
+// unknown length, such that every element seen so far is equally likely to be
+// in the sample.
+type Reservoir[T any] interface {
+	// Offers an element from the stream to the reservoir.
+	Add(T)
+
+	// Returns the current sample, in no particular order. The returned slice is
+	// a copy.
+	Sample() []T
+
+	// Returns the number of elements added to the reservoir.
+	Count() int
+}
+
+// Returns a new reservoir of size k. Equivalent to NewReservoirL. If rng is
+// nil, a generator seeded from the current time is used.
+func NewReservoir[T any](k int, rng *rand.Rand) Reservoir[T] {
+	return NewReservoirL[T](k, rng)
+}
+
+func defaultRand(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return rng
+}
+
+// Returns a uniformly random number in (0, 1).
+func openUniform(rng *rand.Rand) float64 {
+	for {
+		if u := rng.Float64(); u > 0 {
+			return u
+		}
+	}
+}
+
+// A reservoir using Vitter's Algorithm R, which draws a random number for every
+// element in the stream.
+type ReservoirR[T any] struct {
+	k      int
+	sample []T
+	count  int
+	rng    *rand.Rand
+}
+
+// Returns a new reservoir of size k. Panics if k is negative. If rng is nil, a
+// generator seeded from the current time is used.
+func NewReservoirR[T any](k int, rng *rand.Rand) *ReservoirR[T] {
+	if k < 0 {
+		panic("sampling: reservoir size must not be negative")
+	}
+	return &ReservoirR[T]{
+		k:      k,
+		sample: make([]T, 0, k),
+		rng:    defaultRand(rng),
+	}
+}
+
+func (r *ReservoirR[T]) Add(v T) {
+	r.count++
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, v)
+		return
+	}
+	if i := r.rng.Intn(r.count); i < r.k {
+		r.sample[i] = v
+	}
+}
+
+func (r *ReservoirR[T]) Sample() []T {
+	return append([]T(nil), r.sample...)
+}
+
+func (r *ReservoirR[T]) Count() int {
+	return r.count
+}
+
+// A reservoir using Li's Algorithm L, which computes how many elements to skip
+// between replacements. It draws O(k log(n/k)) random numbers for a stream of
+// n elements, so it is much faster than Algorithm R on long streams.
+type ReservoirL[T any] struct {
+	k      int
+	sample []T
+	count  int
+	rng    *rand.Rand
+
+	// The count at which the next element will enter the sample.
+	next int
+
+	// The largest of k uniform random numbers, as maintained by the algorithm.
+	w float64
+}
+
+// Returns a new reservoir of size k. Panics if k is negative. If rng is nil, a
+// generator seeded from the current time is used.
+func NewReservoirL[T any](k int, rng *rand.Rand) *ReservoirL[T] {
+	if k < 0 {
+		panic("sampling: reservoir size must not be negative")
+	}
+	return &ReservoirL[T]{
+		k:      k,
+		sample: make([]T, 0, k),
+		rng:    defaultRand(rng),
+	}
+}
+
+func (r *ReservoirL[T]) Add(v T) {
+	r.count++
+	if r.k == 0 {
+		return
+	}
+
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, v)
+		if len(r.sample) == r.k {
+			r.w = math.Exp(math.Log(openUniform(r.rng)) / float64(r.k))
+			r.skip()
+		}
+		return
+	}
+
+	if r.count == r.next {
+		r.sample[r.rng.Intn(r.k)] = v
+		r.w *= math.Exp(math.Log(openUniform(r.rng)) / float64(r.k))
+		r.skip()
+	}
+}
+
+// Chooses the next element to enter the sample.
+func (r *ReservoirL[T]) skip() {
+	r.next = r.count + int(math.Floor(math.Log(openUniform(r.rng))/math.Log(1-r.w))) + 1
+}
+
+func (r *ReservoirL[T]) Sample() []T {
+	return append([]T(nil), r.sample...)
+}
+
+func (r *ReservoirL[T]) Count() int {
+	return r.count
+}
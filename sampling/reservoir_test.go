@@ -0,0 +1,69 @@
+package sampling
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservoirUniformity(t *testing.T) {
+	const streamLength = 100
+	const k = 10
+	const trials = 20000
+
+	implementations := map[string]func(*rand.Rand) Reservoir[int]{
+		"R": func(rng *rand.Rand) Reservoir[int] { return NewReservoirR[int](k, rng) },
+		"L": func(rng *rand.Rand) Reservoir[int] { return NewReservoirL[int](k, rng) },
+	}
+
+	for name, newReservoir := range implementations {
+		rng := rand.New(rand.NewSource(1))
+		inclusions := make([]int, streamLength)
+		for trial := 0; trial < trials; trial++ {
+			r := newReservoir(rng)
+			for i := 0; i < streamLength; i++ {
+				r.Add(i)
+			}
+			assert.Equal(t, streamLength, r.Count())
+
+			sample := r.Sample()
+			if !assert.Len(t, sample, k, name) {
+				return
+			}
+			for _, v := range sample {
+				inclusions[v]++
+			}
+		}
+
+		// Each element should be included in about k/streamLength of trials.
+		expected := float64(trials) * k / streamLength
+		for v, count := range inclusions {
+			assert.InEpsilon(t, expected, float64(count), 0.1, fmt.Sprintf("%s: element %d", name, v))
+		}
+	}
+}
+
+func TestReservoirShortStream(t *testing.T) {
+	for _, r := range []Reservoir[int]{NewReservoirR[int](5, nil), NewReservoirL[int](5, nil)} {
+		r.Add(1)
+		r.Add(2)
+		assert.ElementsMatch(t, []int{1, 2}, r.Sample())
+	}
+
+	empty := NewReservoir[int](0, nil)
+	empty.Add(1)
+	assert.Empty(t, empty.Sample())
+}
+
+func TestReservoirReproducible(t *testing.T) {
+	sample := func() []int {
+		r := NewReservoir[int](5, rand.New(rand.NewSource(42)))
+		for i := 0; i < 10000; i++ {
+			r.Add(i)
+		}
+		return r.Sample()
+	}
+	assert.Equal(t, sample(), sample())
+}
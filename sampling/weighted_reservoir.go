@@ -0,0 +1,100 @@
+package sampling
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+)
+
+// Maintains a weighted random sample of up to k elements from a stream, using
+// Efraimidis and Spirakis's Algorithm A-Res. The probability that an element is
+// in the sample is proportional to its weight.
+type WeightedReservoir[T any] struct {
+	k     int
+	items weightedItemHeap[T]
+	count int
+	rng   *rand.Rand
+}
+
+type weightedItem[T any] struct {
+	value T
+
+	// The log of the item's A-Res key, u^(1/weight). The sample holds the items
+	// with the largest keys.
+	logKey float64
+}
+
+// Returns a new reservoir of size k. Panics if k is negative. If rng is nil, a
+// generator seeded from the current time is used.
+func NewWeightedReservoir[T any](k int, rng *rand.Rand) *WeightedReservoir[T] {
+	if k < 0 {
+		panic("sampling: reservoir size must not be negative")
+	}
+	return &WeightedReservoir[T]{
+		k:     k,
+		items: make(weightedItemHeap[T], 0, k),
+		rng:   defaultRand(rng),
+	}
+}
+
+// Offers an element with the given weight to the reservoir. Elements whose
+// weight is not positive are never sampled.
+func (r *WeightedReservoir[T]) Add(v T, weight float64) {
+	r.count++
+	if r.k == 0 || !(weight > 0) {
+		return
+	}
+
+	// Work in log space, where the key does not underflow for small weights.
+	item := weightedItem[T]{
+		value:  v,
+		logKey: math.Log(openUniform(r.rng)) / weight,
+	}
+
+	if len(r.items) < r.k {
+		heap.Push(&r.items, item)
+	} else if item.logKey > r.items[0].logKey {
+		r.items[0] = item
+		heap.Fix(&r.items, 0)
+	}
+}
+
+// Returns the current sample, in no particular order.
+func (r *WeightedReservoir[T]) Sample() []T {
+	rv := make([]T, 0, len(r.items))
+	for _, item := range r.items {
+		rv = append(rv, item.value)
+	}
+	return rv
+}
+
+// Returns the number of elements added to the reservoir.
+func (r *WeightedReservoir[T]) Count() int {
+	return r.count
+}
+
+// A min-heap of items by key. Implements heap.Interface.
+type weightedItemHeap[T any] []weightedItem[T]
+
+func (h weightedItemHeap[T]) Len() int {
+	return len(h)
+}
+
+func (h weightedItemHeap[T]) Less(i, j int) bool {
+	return h[i].logKey < h[j].logKey
+}
+
+func (h weightedItemHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *weightedItemHeap[T]) Push(x interface{}) {
+	*h = append(*h, x.(weightedItem[T]))
+}
+
+func (h *weightedItemHeap[T]) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
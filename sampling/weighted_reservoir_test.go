@@ -0,0 +1,39 @@
+package sampling
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedReservoir(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	const trials = 20000
+	heavy := 0
+	for trial := 0; trial < trials; trial++ {
+		r := NewWeightedReservoir[string](1, rng)
+		r.Add("light", 1)
+		r.Add("heavy", 9)
+		r.Add("never", 0)
+		assert.Equal(t, 3, r.Count())
+
+		if r.Sample()[0] == "heavy" {
+			heavy++
+		}
+	}
+	assert.InEpsilon(t, 0.9, float64(heavy)/trials, 0.02)
+}
+
+func TestWeightedReservoirSize(t *testing.T) {
+	r := NewWeightedReservoir[int](3, rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		r.Add(i, float64(i%5+1))
+	}
+	assert.Len(t, r.Sample(), 3)
+
+	r = NewWeightedReservoir[int](3, nil)
+	r.Add(1, 1)
+	assert.Equal(t, []int{1}, r.Sample())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/akitasoftware/go-utils/sets/settest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,3 +77,7 @@ func TestOrderedSetIntersect(t *testing.T) {
 		assert.Equal(t, tc.expected, intersected, tc.name)
 	}
 }
+
+func TestOrderedSetConformance(t *testing.T) {
+	settest.Run(t, func() settest.Set[int] { return NewOrderedSet[int]() })
+}
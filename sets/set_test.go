@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/akitasoftware/go-utils/sets/settest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +67,7 @@ func TestSetIntersect(t *testing.T) {
 		assert.Equal(t, tc.expected, intersected, tc.name)
 	}
 }
+
+func TestSetConformance(t *testing.T) {
+	settest.Run(t, func() settest.Set[int] { return NewSet[int]() })
+}
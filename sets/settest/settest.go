@@ -0,0 +1,146 @@
+// Package settest provides a behavioral test suite for set implementations.
+package settest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The operations exercised by the suite. Satisfied by sets.Set and
+// sets.OrderedSet.
+type Set[T comparable] interface {
+	Insert(...T)
+	Delete(...T)
+	Contains(T) bool
+	ContainsAny(...T) bool
+	ContainsAll(...T) bool
+	IsEmpty() bool
+	Size() int
+	AsSlice() []T
+}
+
+// The number of random operations performed by each model-based check.
+const NumRandomOps = 500
+
+// Elements used by the model-based checks are drawn from [0, numElements), so
+// that inserts and deletes frequently collide.
+const numElements = 50
+
+// Seeds used by the model-based checks. Fixed so that failures are
+// reproducible.
+var seeds = []int64{1, 2, 3, 4, 5}
+
+// Runs a suite of behavioral tests against a set implementation. Each call to
+// newSet must return a new, empty set.
+func Run(t *testing.T, newSet func() Set[int]) {
+	t.Run("Basic", func(t *testing.T) {
+		testBasic(t, newSet())
+	})
+	t.Run("Model", func(t *testing.T) {
+		for _, seed := range seeds {
+			testModel(t, newSet(), seed)
+		}
+	})
+}
+
+func testBasic(t *testing.T, s Set[int]) {
+	assert.True(t, s.IsEmpty())
+	assert.Equal(t, 0, s.Size())
+	assert.False(t, s.Contains(1))
+	assert.False(t, s.ContainsAny(1, 2))
+	assert.True(t, s.ContainsAll(), "vacuous ContainsAll")
+
+	s.Insert(1, 2, 2, 3)
+	assert.False(t, s.IsEmpty())
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains(2))
+	assert.True(t, s.ContainsAny(4, 3))
+	assert.True(t, s.ContainsAll(1, 2, 3))
+	assert.False(t, s.ContainsAll(1, 4))
+	assert.ElementsMatch(t, []int{1, 2, 3}, s.AsSlice())
+
+	s.Delete(2, 4)
+	assert.Equal(t, 2, s.Size())
+	assert.False(t, s.Contains(2))
+	assert.ElementsMatch(t, []int{1, 3}, s.AsSlice())
+
+	s.Delete(1, 3)
+	assert.True(t, s.IsEmpty())
+}
+
+// Performs a random sequence of operations on s, checking after each one that s
+// agrees with a reference set modelled as a Go map.
+func testModel(t *testing.T, s Set[int], seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	model := map[int]struct{}{}
+
+	randomElements := func() []int {
+		rv := make([]int, rng.Intn(4))
+		for i := range rv {
+			rv[i] = rng.Intn(numElements)
+		}
+		return rv
+	}
+
+	for i := 0; i < NumRandomOps; i++ {
+		label := func(msg string) string {
+			return fmt.Sprintf("seed %d, op %d: %s", seed, i, msg)
+		}
+
+		vs := randomElements()
+		switch op := rng.Intn(5); op {
+		case 0, 1:
+			// Bias towards inserts so that the set grows.
+			s.Insert(vs...)
+			for _, v := range vs {
+				model[v] = struct{}{}
+			}
+		case 2:
+			s.Delete(vs...)
+			for _, v := range vs {
+				delete(model, v)
+			}
+		case 3:
+			expected := false
+			for _, v := range vs {
+				_, exists := model[v]
+				expected = expected || exists
+			}
+			if !assert.Equal(t, expected, s.ContainsAny(vs...), label("contains any")) {
+				return
+			}
+		case 4:
+			expected := true
+			for _, v := range vs {
+				_, exists := model[v]
+				expected = expected && exists
+			}
+			if !assert.Equal(t, expected, s.ContainsAll(vs...), label("contains all")) {
+				return
+			}
+		}
+
+		v := rng.Intn(numElements)
+		_, exists := model[v]
+		if !assert.Equal(t, exists, s.Contains(v), label(fmt.Sprintf("contains %d", v))) {
+			return
+		}
+		if !assert.Equal(t, len(model), s.Size(), label("size")) {
+			return
+		}
+		assert.Equal(t, len(model) == 0, s.IsEmpty(), label("is empty"))
+	}
+
+	expected := make([]int, 0, len(model))
+	for v := range model {
+		expected = append(expected, v)
+	}
+	sort.Ints(expected)
+	actual := s.AsSlice()
+	sort.Ints(actual)
+	assert.Equal(t, expected, actual, fmt.Sprintf("seed %d: elements", seed))
+}
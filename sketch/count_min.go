@@ -0,0 +1,91 @@
+package sketch
+
+import (
+	"math"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/pkg/errors"
+)
+
+// A Count-Min sketch, which estimates how many times each element has been
+// added in memory that is independent of the number of distinct elements.
+// Estimates never undercount. With probability at least 1-delta, an estimate
+// overcounts by at most epsilon times the total of all counts added.
+type CountMin[T comparable] struct {
+	width uint64
+	depth int
+
+	// Row-major; row i holds counts for the i-th hash function.
+	counts []uint64
+
+	total  uint64
+	hasher func(T) uint64
+}
+
+// Returns a sketch whose estimates are within epsilon*Total() of the true
+// count with probability at least 1-delta. Panics if epsilon or delta is not
+// in (0, 1).
+func NewCountMin[T comparable](epsilon, delta float64) *CountMin[T] {
+	if !(epsilon > 0 && epsilon < 1) || !(delta > 0 && delta < 1) {
+		panic("sketch: CountMin epsilon and delta must be in (0, 1)")
+	}
+
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return &CountMin[T]{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*uint64(depth)),
+		hasher: hashing.Hash[T],
+	}
+}
+
+// Calls f with the index into counts for v in each row.
+func (s *CountMin[T]) forEachCell(v T, f func(int)) {
+	// Derive the hash functions by double hashing.
+	h1 := s.hasher(v)
+	h2 := hashing.Mix(h1) | 1
+	for row := 0; row < s.depth; row++ {
+		col := (h1 + uint64(row)*h2) % s.width
+		f(row*int(s.width) + int(col))
+	}
+}
+
+// Records count occurrences of v.
+func (s *CountMin[T]) Add(v T, count uint64) {
+	s.forEachCell(v, func(cell int) {
+		s.counts[cell] += count
+	})
+	s.total += count
+}
+
+// Returns an estimate of the number of occurrences of v. The estimate is never
+// less than the true count.
+func (s *CountMin[T]) Estimate(v T) uint64 {
+	rv := uint64(math.MaxUint64)
+	s.forEachCell(v, func(cell int) {
+		if s.counts[cell] < rv {
+			rv = s.counts[cell]
+		}
+	})
+	return rv
+}
+
+// Returns the total of all counts added.
+func (s *CountMin[T]) Total() uint64 {
+	return s.total
+}
+
+// Adds the counts in other to this sketch. Both sketches must have been created
+// with the same parameters.
+func (s *CountMin[T]) Merge(other *CountMin[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return errors.Errorf("cannot merge %dx%d CountMin into %dx%d CountMin", other.depth, other.width, s.depth, s.width)
+	}
+
+	for i, count := range other.counts {
+		s.counts[i] += count
+	}
+	s.total += other.total
+	return nil
+}
@@ -0,0 +1,62 @@
+package sketch
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a Zipf-distributed stream of n endpoint names, along with their exact
+// counts.
+func zipfStream(seed int64, n int) ([]string, map[string]uint64) {
+	rng := rand.New(rand.NewSource(seed))
+	zipf := rand.NewZipf(rng, 1.2, 1, 10000)
+
+	stream := make([]string, n)
+	counts := map[string]uint64{}
+	for i := range stream {
+		stream[i] = fmt.Sprintf("/endpoint/%d", zipf.Uint64())
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+func TestCountMin(t *testing.T) {
+	stream, counts := zipfStream(1, 100000)
+
+	const epsilon = 0.001
+	s := NewCountMin[string](epsilon, 0.01)
+	for _, v := range stream {
+		s.Add(v, 1)
+	}
+	assert.Equal(t, uint64(len(stream)), s.Total())
+
+	maxError := uint64(epsilon * float64(s.Total()))
+	violations := 0
+	for v, count := range counts {
+		estimate := s.Estimate(v)
+		assert.GreaterOrEqual(t, estimate, count, "never undercounts")
+		if estimate-count > maxError {
+			violations++
+		}
+	}
+	assert.LessOrEqual(t, float64(violations), 0.01*float64(len(counts)))
+
+	assert.Equal(t, uint64(0), NewCountMin[string](epsilon, 0.01).Estimate("absent"))
+}
+
+func TestCountMinMerge(t *testing.T) {
+	s1 := NewCountMin[string](0.01, 0.01)
+	s2 := NewCountMin[string](0.01, 0.01)
+	s1.Add("foo", 3)
+	s2.Add("foo", 4)
+	s2.Add("bar", 1)
+
+	assert.NoError(t, s1.Merge(s2))
+	assert.Equal(t, uint64(8), s1.Total())
+	assert.GreaterOrEqual(t, s1.Estimate("foo"), uint64(7))
+
+	assert.Error(t, s1.Merge(NewCountMin[string](0.1, 0.01)))
+}
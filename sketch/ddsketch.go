@@ -0,0 +1,308 @@
+package sketch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+// The default maximum number of bins held by each of a DDSketch's stores. With
+// a relative accuracy of 1%, this covers values spanning about 17 orders of
+// magnitude before any bins are collapsed.
+const DefaultMaxBins = 2048
+
+// The version of the binary encoding produced by DDSketch.MarshalBinary.
+const ddSketchBinaryVersion = 1
+
+// A mergeable quantile sketch with relative-error guarantees, as described in
+// "DDSketch: A Fast and Fully-Mergeable Quantile Sketch with Relative-Error
+// Guarantees" (Masson, Rim and Lee, 2019).
+//
+// Values are counted in logarithmically sized bins, so that any quantile
+// estimate is within a factor of relativeAccuracy of a value that lies at that
+// quantile. Memory is bounded by the number of bins; if values span more bins
+// than allowed, the bins for the smallest magnitudes are collapsed, and
+// accuracy is only maintained for the higher quantiles.
+type DDSketch struct {
+	relativeAccuracy float64
+	maxBins          int
+
+	// Derived from relativeAccuracy.
+	gamma      float64
+	multiplier float64
+
+	// Positive values, and the magnitudes of negative values.
+	positive *denseStore
+	negative *denseStore
+
+	zeroCount uint64
+}
+
+// Returns a new sketch whose quantile estimates are within relativeAccuracy of
+// the true values, using at most DefaultMaxBins bins per sign. Panics if
+// relativeAccuracy is not in (0, 1).
+func NewDDSketch(relativeAccuracy float64) *DDSketch {
+	return NewDDSketchWithMaxBins(relativeAccuracy, DefaultMaxBins)
+}
+
+// Like NewDDSketch, but holds at most maxBins bins per sign. If maxBins is not
+// positive, memory is unbounded.
+func NewDDSketchWithMaxBins(relativeAccuracy float64, maxBins int) *DDSketch {
+	if !(relativeAccuracy > 0 && relativeAccuracy < 1) {
+		panic("sketch: DDSketch relative accuracy must be in (0, 1)")
+	}
+
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		maxBins:          maxBins,
+		gamma:            gamma,
+		multiplier:       1 / math.Log(gamma),
+		positive:         newDenseStore(maxBins),
+		negative:         newDenseStore(maxBins),
+	}
+}
+
+func (s *DDSketch) RelativeAccuracy() float64 {
+	return s.relativeAccuracy
+}
+
+// Returns the index of the bin holding values of magnitude x, which must be
+// positive.
+func (s *DDSketch) index(x float64) int {
+	return int(math.Ceil(math.Log(x) * s.multiplier))
+}
+
+// Returns the value representing the bin with the given index. Every value in
+// the bin is within a factor of relativeAccuracy of this value.
+func (s *DDSketch) value(index int) float64 {
+	return 2 * math.Pow(s.gamma, float64(index)) / (s.gamma + 1)
+}
+
+// Adds a value to the sketch. NaN and infinite values are ignored.
+func (s *DDSketch) Add(x float64) {
+	s.AddWithCount(x, 1)
+}
+
+// Adds count occurrences of a value to the sketch. NaN and infinite values are
+// ignored.
+func (s *DDSketch) AddWithCount(x float64, count uint64) {
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, 0):
+		return
+	case x > 0:
+		s.positive.add(s.index(x), count)
+	case x < 0:
+		s.negative.add(s.index(-x), count)
+	default:
+		s.zeroCount += count
+	}
+}
+
+// Returns the number of values added to the sketch.
+func (s *DDSketch) Count() uint64 {
+	return s.zeroCount + s.positive.total + s.negative.total
+}
+
+func (s *DDSketch) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// Returns an estimate of the q-quantile of the values added to the sketch.
+// Returns None if the sketch is empty or q is not in [0, 1].
+func (s *DDSketch) Quantile(q float64) optionals.Optional[float64] {
+	if s.IsEmpty() || !(q >= 0 && q <= 1) {
+		return optionals.None[float64]()
+	}
+
+	// The rank, counting from zero, of the value to estimate.
+	rank := q * float64(s.Count()-1)
+
+	// Negative values, from the largest magnitude to the smallest.
+	var seen uint64
+	for i := len(s.negative.counts) - 1; i >= 0; i-- {
+		seen += s.negative.counts[i]
+		if float64(seen) > rank {
+			return optionals.Some(-s.value(s.negative.offset + i))
+		}
+	}
+
+	seen += s.zeroCount
+	if float64(seen) > rank {
+		return optionals.Some(0.0)
+	}
+
+	for i, count := range s.positive.counts {
+		seen += count
+		if float64(seen) > rank {
+			return optionals.Some(s.value(s.positive.offset + i))
+		}
+	}
+
+	// Only reachable through rounding error at q = 1.
+	return optionals.Some(s.value(s.positive.maxIndex()))
+}
+
+// Adds the values counted by other to this sketch. Both sketches must have the
+// same relative accuracy and the same maximum number of bins.
+func (s *DDSketch) Merge(other *DDSketch) error {
+	if s.gamma != other.gamma {
+		return errors.Errorf("cannot merge DDSketch with relative accuracy %v into one with relative accuracy %v", other.relativeAccuracy, s.relativeAccuracy)
+	}
+	if s.binLimit() != other.binLimit() {
+		return errors.Errorf("cannot merge DDSketch with at most %d bins into one with at most %d bins", other.maxBins, s.maxBins)
+	}
+
+	s.positive.merge(other.positive)
+	s.negative.merge(other.negative)
+	s.zeroCount += other.zeroCount
+	return nil
+}
+
+// Returns the maximum number of bins per sign, or 0 if it is unbounded.
+func (s *DDSketch) binLimit() int {
+	if s.maxBins <= 0 {
+		return 0
+	}
+	return s.maxBins
+}
+
+// Returns a deep copy of the sketch.
+func (s *DDSketch) Clone() *DDSketch {
+	rv := *s
+	rv.positive = s.positive.clone()
+	rv.negative = s.negative.clone()
+	return &rv
+}
+
+type ddSketchStoreJSON struct {
+	Offset int      `json:"offset"`
+	Counts []uint64 `json:"counts"`
+}
+
+type ddSketchJSON struct {
+	RelativeAccuracy float64           `json:"relative_accuracy"`
+	MaxBins          int               `json:"max_bins"`
+	ZeroCount        uint64            `json:"zero_count"`
+	Positive         ddSketchStoreJSON `json:"positive"`
+	Negative         ddSketchStoreJSON `json:"negative"`
+}
+
+func (s *DDSketch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ddSketchJSON{
+		RelativeAccuracy: s.relativeAccuracy,
+		MaxBins:          s.maxBins,
+		ZeroCount:        s.zeroCount,
+		Positive:         ddSketchStoreJSON{Offset: s.positive.offset, Counts: s.positive.counts},
+		Negative:         ddSketchStoreJSON{Offset: s.negative.offset, Counts: s.negative.counts},
+	})
+}
+
+func (s *DDSketch) UnmarshalJSON(text []byte) error {
+	var decoded ddSketchJSON
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal DDSketch")
+	}
+	if !(decoded.RelativeAccuracy > 0 && decoded.RelativeAccuracy < 1) {
+		return errors.Errorf("invalid DDSketch relative accuracy %v", decoded.RelativeAccuracy)
+	}
+
+	*s = *NewDDSketchWithMaxBins(decoded.RelativeAccuracy, decoded.MaxBins)
+	s.zeroCount = decoded.ZeroCount
+	s.positive.addAll(decoded.Positive.Offset, decoded.Positive.Counts)
+	s.negative.addAll(decoded.Negative.Offset, decoded.Negative.Counts)
+	return nil
+}
+
+// Encodes the sketch compactly, using variable-length integers for the bins.
+func (s *DDSketch) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(ddSketchBinaryVersion)
+	writeUint64(&buf, math.Float64bits(s.relativeAccuracy))
+	writeVarint(&buf, int64(s.maxBins))
+	writeUvarint(&buf, s.zeroCount)
+	for _, store := range []*denseStore{s.positive, s.negative} {
+		writeVarint(&buf, int64(store.offset))
+		writeUvarint(&buf, uint64(len(store.counts)))
+		for _, count := range store.counts {
+			writeUvarint(&buf, count)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *DDSketch) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to read DDSketch version")
+	}
+	if version != ddSketchBinaryVersion {
+		return errors.Errorf("unsupported DDSketch encoding version %d", version)
+	}
+
+	var accuracyBits uint64
+	if err := binary.Read(r, binary.BigEndian, &accuracyBits); err != nil {
+		return errors.Wrap(err, "failed to read DDSketch relative accuracy")
+	}
+	relativeAccuracy := math.Float64frombits(accuracyBits)
+	if !(relativeAccuracy > 0 && relativeAccuracy < 1) {
+		return errors.Errorf("invalid DDSketch relative accuracy %v", relativeAccuracy)
+	}
+
+	maxBins, err := binary.ReadVarint(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to read DDSketch max bins")
+	}
+
+	rv := NewDDSketchWithMaxBins(relativeAccuracy, int(maxBins))
+	if rv.zeroCount, err = binary.ReadUvarint(r); err != nil {
+		return errors.Wrap(err, "failed to read DDSketch zero count")
+	}
+
+	for _, store := range []*denseStore{rv.positive, rv.negative} {
+		offset, err := binary.ReadVarint(r)
+		if err != nil {
+			return errors.Wrap(err, "failed to read DDSketch bin offset")
+		}
+		numBins, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(err, "failed to read DDSketch bin count")
+		}
+		if numBins > uint64(r.Len()) {
+			// Each bin takes at least one byte.
+			return errors.Errorf("DDSketch encoding truncated: %d bins declared", numBins)
+		}
+		counts := make([]uint64, numBins)
+		for i := range counts {
+			if counts[i], err = binary.ReadUvarint(r); err != nil {
+				return errors.Wrap(err, "failed to read DDSketch bin")
+			}
+		}
+		store.addAll(int(offset), counts)
+	}
+
+	*s = *rv
+	return nil
+}
+
+func writeUint64(buf *bytes.Buffer, x uint64) {
+	var scratch [8]byte
+	binary.BigEndian.PutUint64(scratch[:], x)
+	buf.Write(scratch[:])
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buf.Write(scratch[:binary.PutUvarint(scratch[:], x)])
+}
+
+func writeVarint(buf *bytes.Buffer, x int64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buf.Write(scratch[:binary.PutVarint(scratch[:], x)])
+}
@@ -0,0 +1,161 @@
+package sketch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+var testQuantiles = []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999, 1}
+
+// Returns the exact q-quantile of sorted, using the same lower-rank definition
+// as DDSketch.
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func assertRelativeAccuracy(t *testing.T, s *DDSketch, values []float64, label string) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	for _, q := range testQuantiles {
+		expected := exactQuantile(sorted, q)
+		actual, exists := s.Quantile(q).Get()
+		if assert.True(t, exists, label) {
+			assert.LessOrEqual(t, math.Abs(actual-expected), s.RelativeAccuracy()*math.Abs(expected)+1e-12,
+				fmt.Sprintf("%s: q=%v expected %v got %v", label, q, expected, actual))
+		}
+	}
+}
+
+func TestDDSketchAccuracy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	distributions := map[string]func() float64{
+		"uniform":     func() float64 { return rng.Float64() * 1000 },
+		"exponential": func() float64 { return rng.ExpFloat64() * 50 },
+		"lognormal":   func() float64 { return math.Exp(rng.NormFloat64() * 3) },
+		"normal":      func() float64 { return rng.NormFloat64() * 100 },
+		"with zeros": func() float64 {
+			if rng.Intn(4) == 0 {
+				return 0
+			}
+			return rng.Float64()
+		},
+	}
+
+	for name, next := range distributions {
+		for _, accuracy := range []float64{0.01, 0.05} {
+			s := NewDDSketch(accuracy)
+			values := make([]float64, 20000)
+			for i := range values {
+				values[i] = next()
+				s.Add(values[i])
+			}
+
+			assert.Equal(t, uint64(len(values)), s.Count(), name)
+			assertRelativeAccuracy(t, s, values, fmt.Sprintf("%s, accuracy %v", name, accuracy))
+		}
+	}
+}
+
+func TestDDSketchEmpty(t *testing.T) {
+	s := NewDDSketch(0.01)
+	assert.True(t, s.IsEmpty())
+	assert.Equal(t, optionals.None[float64](), s.Quantile(0.5))
+
+	s.Add(math.NaN())
+	s.Add(math.Inf(1))
+	assert.True(t, s.IsEmpty())
+
+	s.Add(1)
+	assert.Equal(t, optionals.None[float64](), s.Quantile(-0.1))
+	assert.Equal(t, optionals.None[float64](), s.Quantile(1.1))
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	whole := NewDDSketch(0.01)
+	shards := []*DDSketch{NewDDSketch(0.01), NewDDSketch(0.01), NewDDSketch(0.01)}
+
+	values := make([]float64, 10000)
+	for i := range values {
+		values[i] = rng.NormFloat64() * 1000
+		whole.Add(values[i])
+		shards[i%len(shards)].Add(values[i])
+	}
+
+	merged := NewDDSketch(0.01)
+	for _, shard := range shards {
+		assert.NoError(t, merged.Merge(shard))
+	}
+
+	assert.Equal(t, whole.Count(), merged.Count())
+	for _, q := range testQuantiles {
+		assert.Equal(t, whole.Quantile(q), merged.Quantile(q), fmt.Sprintf("q=%v", q))
+	}
+	assertRelativeAccuracy(t, merged, values, "merged")
+
+	assert.Error(t, merged.Merge(NewDDSketch(0.05)))
+	assert.Error(t, merged.Merge(NewDDSketchWithMaxBins(0.01, 100)))
+	assert.NoError(t, NewDDSketchWithMaxBins(0.01, 0).Merge(NewDDSketchWithMaxBins(0.01, -1)), "both unbounded")
+}
+
+func TestDDSketchBoundedMemory(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	s := NewDDSketchWithMaxBins(0.01, 100)
+
+	// Values spanning many orders of magnitude.
+	values := make([]float64, 10000)
+	for i := range values {
+		values[i] = math.Pow(10, rng.Float64()*20-10)
+		s.Add(values[i])
+	}
+
+	assert.LessOrEqual(t, len(s.positive.counts), 100)
+	assert.Equal(t, uint64(len(values)), s.Count())
+
+	// Only the lowest bins are collapsed, so the highest quantiles stay
+	// accurate.
+	sort.Float64s(values)
+	for _, q := range []float64{0.99, 0.999, 1} {
+		expected := exactQuantile(values, q)
+		actual := s.Quantile(q).GetOrDefault(0)
+		assert.LessOrEqual(t, math.Abs(actual-expected), 0.01*expected, fmt.Sprintf("q=%v", q))
+	}
+}
+
+func TestDDSketchSerialization(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	s := NewDDSketch(0.02)
+	for i := 0; i < 1000; i++ {
+		s.Add(rng.NormFloat64() * 10)
+	}
+	s.Add(0)
+
+	bs, err := json.Marshal(s)
+	assert.NoError(t, err)
+	var fromJSON DDSketch
+	assert.NoError(t, json.Unmarshal(bs, &fromJSON))
+
+	bs, err = s.MarshalBinary()
+	assert.NoError(t, err)
+	var fromBinary DDSketch
+	assert.NoError(t, fromBinary.UnmarshalBinary(bs))
+
+	for _, deserialized := range []*DDSketch{&fromJSON, &fromBinary} {
+		assert.Equal(t, s.Count(), deserialized.Count())
+		assert.Equal(t, s.RelativeAccuracy(), deserialized.RelativeAccuracy())
+		for _, q := range testQuantiles {
+			assert.Equal(t, s.Quantile(q), deserialized.Quantile(q), fmt.Sprintf("q=%v", q))
+		}
+	}
+
+	var truncated DDSketch
+	assert.Error(t, truncated.UnmarshalBinary(bs[:len(bs)/2]))
+}
@@ -0,0 +1,146 @@
+package sketch
+
+import (
+	"encoding/json"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/pkg/errors"
+)
+
+// Counts distinct elements exactly, using a sets.Set, until more than a
+// threshold number have been inserted. It then switches to a HyperLogLog
+// sketch, bounding memory at the cost of exactness.
+type DistinctCounter[T comparable] struct {
+	threshold int
+	precision int
+
+	// Exactly one of exact and sketch is non-nil.
+	exact  sets.Set[T]
+	sketch *HyperLogLog[T]
+}
+
+// Returns a counter that is exact for up to threshold distinct elements, and
+// that uses a HyperLogLog with the given precision beyond that. Panics if
+// precision is out of range.
+func NewDistinctCounter[T comparable](threshold int, precision int) *DistinctCounter[T] {
+	// Fail early on invalid precision, rather than on switching to a sketch.
+	NewHyperLogLog[T](precision)
+
+	return &DistinctCounter[T]{
+		threshold: threshold,
+		precision: precision,
+		exact:     sets.NewSet[T](),
+	}
+}
+
+func (c *DistinctCounter[T]) Insert(vs ...T) {
+	if c.sketch != nil {
+		c.sketch.Insert(vs...)
+		return
+	}
+
+	c.exact.Insert(vs...)
+	if c.exact.Size() > c.threshold {
+		c.switchToSketch()
+	}
+}
+
+func (c *DistinctCounter[T]) switchToSketch() {
+	c.sketch = NewHyperLogLog[T](c.precision)
+	for v := range c.exact {
+		c.sketch.Insert(v)
+	}
+	c.exact = nil
+}
+
+// Returns the number of distinct elements inserted. This is an estimate if
+// IsExact is false.
+func (c *DistinctCounter[T]) Size() int {
+	if c.sketch != nil {
+		return int(c.sketch.Estimate())
+	}
+	return c.exact.Size()
+}
+
+func (c *DistinctCounter[T]) IsEmpty() bool {
+	if c.sketch != nil {
+		return c.sketch.IsEmpty()
+	}
+	return c.exact.IsEmpty()
+}
+
+// Returns true if Size is exact.
+func (c *DistinctCounter[T]) IsExact() bool {
+	return c.sketch == nil
+}
+
+// Returns the inserted elements if the count is still exact. Changes to the
+// returned set will be reflected in this counter.
+func (c *DistinctCounter[T]) Elements() optionals.Optional[sets.Set[T]] {
+	if c.sketch != nil {
+		return optionals.None[sets.Set[T]]()
+	}
+	return optionals.Some(c.exact)
+}
+
+// Adds the elements counted by other to this counter. Both counters must have
+// the same precision. The merged counter remains exact only if both were exact
+// and their union is within this counter's threshold.
+func (c *DistinctCounter[T]) Merge(other *DistinctCounter[T]) error {
+	if c.precision != other.precision {
+		return errors.Errorf("cannot merge DistinctCounter with precision %d into one with precision %d", other.precision, c.precision)
+	}
+
+	if other.sketch == nil {
+		c.Insert(other.exact.AsSlice()...)
+		return nil
+	}
+
+	if c.sketch == nil {
+		c.switchToSketch()
+	}
+	return c.sketch.Merge(other.sketch)
+}
+
+type distinctCounterJSON[T comparable] struct {
+	Threshold int             `json:"threshold"`
+	Precision int             `json:"precision"`
+	Elements  sets.Set[T]     `json:"elements,omitempty"`
+	Sketch    *HyperLogLog[T] `json:"sketch,omitempty"`
+}
+
+func (c *DistinctCounter[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(distinctCounterJSON[T]{
+		Threshold: c.threshold,
+		Precision: c.precision,
+		Elements:  c.exact,
+		Sketch:    c.sketch,
+	})
+}
+
+func (c *DistinctCounter[T]) UnmarshalJSON(text []byte) error {
+	var decoded distinctCounterJSON[T]
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal DistinctCounter")
+	}
+	if decoded.Precision < MinHyperLogLogPrecision || decoded.Precision > MaxHyperLogLogPrecision {
+		return errors.Errorf("invalid DistinctCounter precision %d", decoded.Precision)
+	}
+	if decoded.Sketch != nil && decoded.Sketch.Precision() != decoded.Precision {
+		return errors.Errorf("DistinctCounter with precision %d has a HyperLogLog with precision %d", decoded.Precision, decoded.Sketch.Precision())
+	}
+
+	*c = DistinctCounter[T]{
+		threshold: decoded.Threshold,
+		precision: decoded.Precision,
+		exact:     decoded.Elements,
+		sketch:    decoded.Sketch,
+	}
+	if c.sketch != nil {
+		c.exact = nil
+	} else if c.exact == nil {
+		c.exact = sets.NewSet[T]()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package sketch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistinctCounter(t *testing.T) {
+	c := NewDistinctCounter[int](100, 12)
+	assert.True(t, c.IsEmpty())
+
+	for i := 0; i < 100; i++ {
+		c.Insert(i, i)
+	}
+	assert.True(t, c.IsExact())
+	assert.Equal(t, 100, c.Size())
+	assert.Equal(t, 100, c.Elements().GetOrDefault(nil).Size())
+
+	c.Insert(100)
+	assert.False(t, c.IsExact())
+	assert.True(t, c.Elements().IsNone())
+	assertEstimateWithin(t, 101, uint64(c.Size()), 0.05, "after switch")
+
+	for i := 0; i < 10000; i++ {
+		c.Insert(i)
+	}
+	assertEstimateWithin(t, 10000, uint64(c.Size()), 0.07, "large")
+}
+
+func TestDistinctCounterMerge(t *testing.T) {
+	small1 := NewDistinctCounter[int](10, 12)
+	small1.Insert(1, 2, 3)
+	small2 := NewDistinctCounter[int](10, 12)
+	small2.Insert(3, 4)
+
+	assert.NoError(t, small1.Merge(small2))
+	assert.True(t, small1.IsExact())
+	assert.Equal(t, sets.NewSet(1, 2, 3, 4), small1.Elements().GetOrDefault(nil))
+
+	large := NewDistinctCounter[int](10, 12)
+	for i := 0; i < 1000; i++ {
+		large.Insert(i)
+	}
+	assert.NoError(t, small1.Merge(large))
+	assert.False(t, small1.IsExact())
+	assertEstimateWithin(t, 1000, uint64(small1.Size()), 0.07, "merged")
+
+	assert.Error(t, small1.Merge(NewDistinctCounter[int](10, 10)))
+}
+
+func TestDistinctCounterJSON(t *testing.T) {
+	for _, n := range []int{0, 5, 50} {
+		c := NewDistinctCounter[string](10, 8)
+		for i := 0; i < n; i++ {
+			c.Insert(string(rune('a' + i)))
+		}
+
+		bs, err := json.Marshal(c)
+		assert.NoError(t, err)
+
+		var deserialized DistinctCounter[string]
+		assert.NoError(t, json.Unmarshal(bs, &deserialized))
+		assert.Equal(t, c.IsExact(), deserialized.IsExact())
+		assert.Equal(t, c.Size(), deserialized.Size())
+
+		// The deserialized counter remains usable.
+		deserialized.Insert("new")
+	}
+
+	// The sketch must have the counter's precision, so that merging and
+	// switching to a sketch stay consistent.
+	sketch := NewHyperLogLog[string](10)
+	sketch.Insert("a")
+	bs, err := json.Marshal(distinctCounterJSON[string]{Threshold: 10, Precision: 8, Sketch: sketch})
+	assert.NoError(t, err)
+	var deserialized DistinctCounter[string]
+	assert.Error(t, json.Unmarshal(bs, &deserialized))
+}
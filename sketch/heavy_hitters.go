@@ -0,0 +1,157 @@
+package sketch
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/akitasoftware/go-utils/optionals"
+)
+
+// An element tracked by HeavyHitters. Its true count is between Count-Error and
+// Count.
+type HeavyHitter[T comparable] struct {
+	Item  T
+	Count uint64
+	Error uint64
+}
+
+// Tracks the most frequent elements of a stream using the Space-Saving
+// algorithm (Metwally, Agrawal and El Abbadi, 2005), holding at most capacity
+// counters. Any element occurring more than Total()/capacity times is
+// guaranteed to be tracked.
+type HeavyHitters[T comparable] struct {
+	capacity int
+
+	// A min-heap of counters, ordered by count.
+	counters heavyHitterHeap[T]
+
+	// Maps tracked elements to their counters.
+	index map[T]*heavyHitterCounter[T]
+
+	total uint64
+}
+
+type heavyHitterCounter[T comparable] struct {
+	HeavyHitter[T]
+
+	// Position in the heap.
+	heapIndex int
+}
+
+// Returns a tracker holding at most capacity counters. Panics if capacity is
+// not positive.
+func NewHeavyHitters[T comparable](capacity int) *HeavyHitters[T] {
+	if capacity <= 0 {
+		panic("sketch: HeavyHitters capacity must be positive")
+	}
+
+	return &HeavyHitters[T]{
+		capacity: capacity,
+		counters: make(heavyHitterHeap[T], 0, capacity),
+		index:    make(map[T]*heavyHitterCounter[T], capacity),
+	}
+}
+
+// Records count occurrences of v. Does nothing if count is zero, so that v
+// neither evicts a tracked element nor starts being tracked.
+func (h *HeavyHitters[T]) Add(v T, count uint64) {
+	if count == 0 {
+		return
+	}
+	h.total += count
+
+	if counter, exists := h.index[v]; exists {
+		counter.Count += count
+		heap.Fix(&h.counters, counter.heapIndex)
+		return
+	}
+
+	if len(h.counters) < h.capacity {
+		counter := &heavyHitterCounter[T]{
+			HeavyHitter: HeavyHitter[T]{Item: v, Count: count},
+		}
+		heap.Push(&h.counters, counter)
+		h.index[v] = counter
+		return
+	}
+
+	// Replace the element with the smallest count, which v may have been
+	// responsible for.
+	counter := h.counters[0]
+	delete(h.index, counter.Item)
+	counter.Item = v
+	counter.Error = counter.Count
+	counter.Count += count
+	heap.Fix(&h.counters, 0)
+	h.index[v] = counter
+}
+
+// Returns the total of all counts added.
+func (h *HeavyHitters[T]) Total() uint64 {
+	return h.total
+}
+
+// Returns the counter for v, or None if v is not tracked.
+func (h *HeavyHitters[T]) Get(v T) optionals.Optional[HeavyHitter[T]] {
+	if counter, exists := h.index[v]; exists {
+		return optionals.Some(counter.HeavyHitter)
+	}
+	return optionals.None[HeavyHitter[T]]()
+}
+
+// Returns up to k tracked elements with the highest counts, in descending
+// order of count. Ties are broken by ascending error, so that elements with
+// more certain counts come first. If k is not positive, returns an empty
+// slice.
+func (h *HeavyHitters[T]) TopK(k int) []HeavyHitter[T] {
+	if k <= 0 {
+		return []HeavyHitter[T]{}
+	}
+
+	rv := make([]HeavyHitter[T], 0, len(h.counters))
+	for _, counter := range h.counters {
+		rv = append(rv, counter.HeavyHitter)
+	}
+
+	sort.SliceStable(rv, func(i, j int) bool {
+		if rv[i].Count != rv[j].Count {
+			return rv[i].Count > rv[j].Count
+		}
+		return rv[i].Error < rv[j].Error
+	})
+
+	if k < len(rv) {
+		rv = rv[:k]
+	}
+	return rv
+}
+
+// Implements heap.Interface.
+type heavyHitterHeap[T comparable] []*heavyHitterCounter[T]
+
+func (h heavyHitterHeap[T]) Len() int {
+	return len(h)
+}
+
+func (h heavyHitterHeap[T]) Less(i, j int) bool {
+	return h[i].Count < h[j].Count
+}
+
+func (h heavyHitterHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *heavyHitterHeap[T]) Push(x interface{}) {
+	counter := x.(*heavyHitterCounter[T])
+	counter.heapIndex = len(*h)
+	*h = append(*h, counter)
+}
+
+func (h *heavyHitterHeap[T]) Pop() interface{} {
+	old := *h
+	counter := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return counter
+}
@@ -0,0 +1,82 @@
+package sketch
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeavyHitters(t *testing.T) {
+	stream, counts := zipfStream(2, 100000)
+
+	h := NewHeavyHitters[string](100)
+	for _, v := range stream {
+		h.Add(v, 1)
+	}
+	assert.Equal(t, uint64(len(stream)), h.Total())
+
+	// Compute the exact top 10.
+	type itemCount struct {
+		item  string
+		count uint64
+	}
+	var exact []itemCount
+	for item, count := range counts {
+		exact = append(exact, itemCount{item, count})
+	}
+	sort.Slice(exact, func(i, j int) bool { return exact[i].count > exact[j].count })
+
+	top := h.TopK(10)
+	assert.Len(t, top, 10)
+	for i, hitter := range top {
+		assert.Equal(t, exact[i].item, hitter.Item, "rank %d", i)
+
+		// The true count is within the error bounds.
+		assert.GreaterOrEqual(t, hitter.Count, counts[hitter.Item])
+		assert.LessOrEqual(t, hitter.Count-hitter.Error, counts[hitter.Item])
+
+		if i > 0 {
+			assert.GreaterOrEqual(t, top[i-1].Count, hitter.Count, "descending order")
+		}
+	}
+
+	assert.Equal(t, optionals.Some(top[0]), h.Get(top[0].Item))
+	assert.Equal(t, optionals.None[HeavyHitter[string]](), h.Get("/never/seen"))
+}
+
+func TestHeavyHittersEviction(t *testing.T) {
+	h := NewHeavyHitters[string](2)
+	h.Add("a", 5)
+	h.Add("b", 2)
+	h.Add("c", 1)
+
+	// "c" replaces "b", inheriting its count as error.
+	assert.Equal(t, []HeavyHitter[string]{
+		{Item: "a", Count: 5},
+		{Item: "c", Count: 3, Error: 2},
+	}, h.TopK(5))
+	assert.True(t, h.Get("b").IsNone())
+
+	// Adding nothing evicts nothing.
+	h.Add("d", 0)
+	assert.True(t, h.Get("d").IsNone())
+	assert.True(t, h.Get("c").IsSome())
+	assert.Equal(t, uint64(8), h.Total())
+
+	empty := NewHeavyHitters[string](2)
+	empty.Add("a", 0)
+	assert.Empty(t, empty.TopK(5))
+}
+
+func TestHeavyHittersTopKBounds(t *testing.T) {
+	h := NewHeavyHitters[string](3)
+	h.Add("a", 2)
+	h.Add("b", 1)
+
+	assert.Empty(t, h.TopK(0))
+	assert.Empty(t, h.TopK(-1))
+	assert.Len(t, h.TopK(1), 1)
+	assert.Len(t, h.TopK(10), 2)
+}
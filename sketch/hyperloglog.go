@@ -0,0 +1,208 @@
+package sketch
+
+import (
+	"encoding/json"
+	"math"
+	"math/bits"
+
+	"github.com/akitasoftware/go-utils/internal/hashing"
+	"github.com/pkg/errors"
+)
+
+const (
+	MinHyperLogLogPrecision = 4
+	MaxHyperLogLogPrecision = 18
+
+	// Gives a standard error of about 0.8% using 16 KiB.
+	DefaultHyperLogLogPrecision = 14
+)
+
+// The version of the binary encoding produced by HyperLogLog.MarshalBinary.
+const hyperLogLogBinaryVersion = 1
+
+// Estimates the number of distinct elements inserted, using 2^precision bytes
+// of memory regardless of how many elements are inserted. The standard error
+// of the estimate is about 1.04/sqrt(2^precision).
+//
+// Elements are hashed with a hash function that is stable across processes for
+// strings, numbers, and structs and arrays of these, so serialized sketches of
+// such elements remain mergeable after a restart.
+type HyperLogLog[T comparable] struct {
+	precision uint8
+
+	// registers[i] is the maximum rank seen among hashes whose top precision
+	// bits are i.
+	registers []uint8
+
+	hasher func(T) uint64
+}
+
+// Returns a new, empty sketch. Panics if precision is not between
+// MinHyperLogLogPrecision and MaxHyperLogLogPrecision.
+func NewHyperLogLog[T comparable](precision int) *HyperLogLog[T] {
+	return NewHyperLogLogWithHasher(precision, hashing.Hash[T])
+}
+
+// Like NewHyperLogLog, but hashes elements with the given function, which must
+// produce well-distributed 64-bit hashes.
+func NewHyperLogLogWithHasher[T comparable](precision int, hasher func(T) uint64) *HyperLogLog[T] {
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		panic("sketch: HyperLogLog precision out of range")
+	}
+
+	return &HyperLogLog[T]{
+		precision: uint8(precision),
+		registers: make([]uint8, 1<<precision),
+		hasher:    hasher,
+	}
+}
+
+func (h *HyperLogLog[T]) Precision() int {
+	return int(h.precision)
+}
+
+func (h *HyperLogLog[T]) Insert(vs ...T) {
+	for _, v := range vs {
+		h.insertHash(h.hasher(v))
+	}
+}
+
+func (h *HyperLogLog[T]) insertHash(hash uint64) {
+	index := hash >> (64 - h.precision)
+
+	// The rank is the position of the first set bit in the remaining bits. Set a
+	// sentinel bit so that the rank is bounded even if they are all zero.
+	rest := hash<<h.precision | 1<<(h.precision-1)
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// Returns the estimated number of distinct elements inserted.
+func (h *HyperLogLog[T]) Estimate() uint64 {
+	m := float64(len(h.registers))
+
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+
+	// Use linear counting for small cardinalities, where it is more accurate.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+// Returns true if no elements have been inserted.
+func (h *HyperLogLog[T]) IsEmpty() bool {
+	for _, r := range h.registers {
+		if r != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Adds the elements counted by other to this sketch, so that the estimate is
+// of the union of the two. Both sketches must have the same precision.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.precision != other.precision {
+		return errors.Errorf("cannot merge HyperLogLog with precision %d into one with precision %d", other.precision, h.precision)
+	}
+
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Returns a deep copy of the sketch.
+func (h *HyperLogLog[T]) Clone() *HyperLogLog[T] {
+	rv := *h
+	rv.registers = append([]uint8(nil), h.registers...)
+	return &rv
+}
+
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	rv := make([]byte, 0, 2+len(h.registers))
+	rv = append(rv, hyperLogLogBinaryVersion, h.precision)
+	return append(rv, h.registers...), nil
+}
+
+// Restores a sketch encoded by MarshalBinary. The sketch's hash function is
+// kept, or set to the default if it has none.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("HyperLogLog encoding truncated")
+	}
+	if data[0] != hyperLogLogBinaryVersion {
+		return errors.Errorf("unsupported HyperLogLog encoding version %d", data[0])
+	}
+	return h.restore(int(data[1]), data[2:])
+}
+
+type hyperLogLogJSON struct {
+	Precision int    `json:"precision"`
+	Registers []byte `json:"registers"`
+}
+
+func (h *HyperLogLog[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hyperLogLogJSON{
+		Precision: int(h.precision),
+		Registers: h.registers,
+	})
+}
+
+// Restores a sketch encoded by MarshalJSON. The sketch's hash function is kept,
+// or set to the default if it has none.
+func (h *HyperLogLog[T]) UnmarshalJSON(text []byte) error {
+	var decoded hyperLogLogJSON
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal HyperLogLog")
+	}
+	return h.restore(decoded.Precision, decoded.Registers)
+}
+
+func (h *HyperLogLog[T]) restore(precision int, registers []byte) error {
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		return errors.Errorf("invalid HyperLogLog precision %d", precision)
+	}
+	if len(registers) != 1<<precision {
+		return errors.Errorf("HyperLogLog with precision %d must have %d registers, not %d", precision, 1<<precision, len(registers))
+	}
+
+	hasher := h.hasher
+	if hasher == nil {
+		hasher = hashing.Hash[T]
+	}
+	*h = HyperLogLog[T]{
+		precision: uint8(precision),
+		registers: append([]byte(nil), registers...),
+		hasher:    hasher,
+	}
+	return nil
+}
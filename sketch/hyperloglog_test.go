@@ -0,0 +1,77 @@
+package sketch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertEstimateWithin(t *testing.T, expected int, actual uint64, relativeError float64, label string) {
+	assert.LessOrEqual(t, math.Abs(float64(actual)-float64(expected)), relativeError*float64(expected),
+		fmt.Sprintf("%s: expected about %d, got %d", label, expected, actual))
+}
+
+func TestHyperLogLogAccuracy(t *testing.T) {
+	for _, precision := range []int{10, 14} {
+		// Allow four standard errors.
+		tolerance := 4 * 1.04 / math.Sqrt(float64(int(1)<<precision))
+
+		for _, n := range []int{10, 1000, 100000} {
+			h := NewHyperLogLog[string](precision)
+			for i := 0; i < n; i++ {
+				// Insert each element twice; duplicates must not be counted.
+				h.Insert(fmt.Sprintf("user-%d", i), fmt.Sprintf("user-%d", i))
+			}
+			assertEstimateWithin(t, n, h.Estimate(), tolerance, fmt.Sprintf("precision %d, n %d", precision, n))
+		}
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	h1 := NewHyperLogLog[int](12)
+	h2 := NewHyperLogLog[int](12)
+	for i := 0; i < 20000; i++ {
+		h1.Insert(i)
+		h2.Insert(i + 10000)
+	}
+
+	assert.NoError(t, h1.Merge(h2))
+	assertEstimateWithin(t, 30000, h1.Estimate(), 0.07, "merged")
+
+	assert.Error(t, h1.Merge(NewHyperLogLog[int](10)))
+}
+
+func TestHyperLogLogSerialization(t *testing.T) {
+	h := NewHyperLogLog[int](8)
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, uint64(0), h.Estimate())
+
+	for i := 0; i < 500; i++ {
+		h.Insert(i)
+	}
+
+	bs, err := h.MarshalBinary()
+	assert.NoError(t, err)
+	var fromBinary HyperLogLog[int]
+	assert.NoError(t, fromBinary.UnmarshalBinary(bs))
+
+	bs, err = json.Marshal(h)
+	assert.NoError(t, err)
+	var fromJSON HyperLogLog[int]
+	assert.NoError(t, json.Unmarshal(bs, &fromJSON))
+
+	for _, deserialized := range []*HyperLogLog[int]{&fromBinary, &fromJSON} {
+		assert.Equal(t, h.Precision(), deserialized.Precision())
+		assert.Equal(t, h.Estimate(), deserialized.Estimate())
+
+		// The deserialized sketch continues to count consistently.
+		deserialized.Insert(1, 2, 3)
+		assert.Equal(t, h.Estimate(), deserialized.Estimate())
+	}
+
+	var invalid HyperLogLog[int]
+	assert.Error(t, invalid.UnmarshalBinary([]byte{hyperLogLogBinaryVersion, 8, 0}))
+}
@@ -0,0 +1,112 @@
+package sketch
+
+// Counts values by bin index, holding the counts for a contiguous range of
+// indices in a slice. If the range would exceed maxBins, the lowest bins are
+// collapsed into one, which bounds memory at the cost of accuracy for the
+// smallest values.
+type denseStore struct {
+	// counts[i] is the count for index offset+i.
+	counts []uint64
+	offset int
+	total  uint64
+
+	// If positive, the maximum length of counts.
+	maxBins int
+}
+
+func newDenseStore(maxBins int) *denseStore {
+	return &denseStore{maxBins: maxBins}
+}
+
+func (s *denseStore) isEmpty() bool {
+	return len(s.counts) == 0
+}
+
+// The highest index whose count is held in the store. Only meaningful if the
+// store is not empty.
+func (s *denseStore) maxIndex() int {
+	return s.offset + len(s.counts) - 1
+}
+
+func (s *denseStore) add(index int, count uint64) {
+	if count == 0 {
+		return
+	}
+
+	s.extendRange(index, index)
+	if index < s.offset {
+		// Collapsed into the lowest bin.
+		index = s.offset
+	}
+	s.counts[index-s.offset] += count
+	s.total += count
+}
+
+// Adds the counts in other to this store.
+func (s *denseStore) merge(other *denseStore) {
+	s.addAll(other.offset, other.counts)
+}
+
+// Adds counts[i] to the count for index offset+i, for each i.
+func (s *denseStore) addAll(offset int, counts []uint64) {
+	if len(counts) == 0 {
+		return
+	}
+
+	// Extend the range once up front, rather than once per index.
+	s.extendRange(offset, offset+len(counts)-1)
+	for i, count := range counts {
+		s.add(offset+i, count)
+	}
+}
+
+// Grows the range of indices held by the store to cover [low, high], collapsing
+// the lowest bins if necessary.
+func (s *denseStore) extendRange(low, high int) {
+	if s.isEmpty() {
+		if s.maxBins > 0 && high-low+1 > s.maxBins {
+			low = high - s.maxBins + 1
+		}
+		s.offset = low
+		s.counts = make([]uint64, high-low+1)
+		return
+	}
+
+	if low >= s.offset && high <= s.maxIndex() {
+		return
+	}
+
+	newLow := s.offset
+	if low < newLow {
+		newLow = low
+	}
+	newHigh := s.maxIndex()
+	if high > newHigh {
+		newHigh = high
+	}
+	if s.maxBins > 0 && newHigh-newLow+1 > s.maxBins {
+		newLow = newHigh - s.maxBins + 1
+	}
+	if newLow == s.offset && newHigh == s.maxIndex() {
+		// Only lower indices were requested, and those are already collapsed
+		// into the lowest bin.
+		return
+	}
+
+	counts := make([]uint64, newHigh-newLow+1)
+	for i, count := range s.counts {
+		index := s.offset + i
+		if index < newLow {
+			index = newLow
+		}
+		counts[index-newLow] += count
+	}
+	s.counts = counts
+	s.offset = newLow
+}
+
+func (s *denseStore) clone() *denseStore {
+	rv := *s
+	rv.counts = append([]uint64(nil), s.counts...)
+	return &rv
+}
@@ -0,0 +1,181 @@
+package slices
+
+import (
+	"math"
+	"sort"
+
+	"github.com/akitasoftware/go-utils/constraints"
+	"github.com/akitasoftware/go-utils/optionals"
+)
+
+// Returns true if T is a floating-point type.
+func isFloat[T constraints.Number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+// Returns the sum of the elements of s, or zero if s is empty. Floating-point
+// sums use Neumaier's compensated summation, so that rounding error does not
+// accumulate with the length of s.
+func Sum[T constraints.Number](s []T) T {
+	if isFloat[T]() {
+		return T(compensatedSum(s))
+	}
+
+	var sum T
+	for _, x := range s {
+		sum += x
+	}
+	return sum
+}
+
+// Sums the elements of s as float64s, using Neumaier's variant of Kahan
+// summation. If an element is infinite or NaN, or the sum overflows, the
+// result is the same as for naive summation.
+func compensatedSum[T constraints.Number](s []T) float64 {
+	var sum, compensation float64
+	for i, t := range s {
+		x := float64(t)
+		next := sum + x
+		if math.IsInf(next, 0) || math.IsNaN(next) {
+			// The compensation would compute Inf-Inf, giving NaN. No further
+			// elements can make the sum finite again, so add them naively.
+			for _, t := range s[i+1:] {
+				next += float64(t)
+			}
+			return next
+		}
+
+		// Recover the low-order bits lost when computing next.
+		if abs(sum) >= abs(x) {
+			compensation += (sum - next) + x
+		} else {
+			compensation += (x - next) + sum
+		}
+		sum = next
+	}
+	return sum + compensation
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Returns the product of the elements of s, or one if s is empty.
+func Product[T constraints.Number](s []T) T {
+	var product T = 1
+	for _, x := range s {
+		product *= x
+	}
+	return product
+}
+
+// Returns the arithmetic mean of the elements of s, or None if s is empty.
+func Mean[T constraints.Number](s []T) optionals.Optional[float64] {
+	if len(s) == 0 {
+		return optionals.None[float64]()
+	}
+	return optionals.Some(compensatedSum(s) / float64(len(s)))
+}
+
+// Returns the median of the elements of s, or None if s is empty. If s has an
+// even number of elements, the mean of the middle two is returned. Does not
+// modify s.
+func Median[T constraints.Number](s []T) optionals.Optional[float64] {
+	if len(s) == 0 {
+		return optionals.None[float64]()
+	}
+
+	sorted := append([]T(nil), s...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return optionals.Some(float64(sorted[mid]))
+	}
+	return optionals.Some((float64(sorted[mid-1]) + float64(sorted[mid])) / 2)
+}
+
+// Returns the most frequent element of s, or None if s is empty. Ties are
+// broken in favour of the element that appears first in s.
+func Mode[T comparable](s []T) optionals.Optional[T] {
+	if len(s) == 0 {
+		return optionals.None[T]()
+	}
+
+	counts := make(map[T]int, len(s))
+	mode, modeCount := s[0], 0
+	for _, t := range s {
+		counts[t]++
+	}
+	for _, t := range s {
+		if counts[t] > modeCount {
+			mode, modeCount = t, counts[t]
+		}
+	}
+	return optionals.Some(mode)
+}
+
+// Returns the smallest element of s, or None if s is empty.
+func MinOf[T constraints.Number](s []T) optionals.Optional[T] {
+	return elementAt(s, ArgMin(s))
+}
+
+// Returns the largest element of s, or None if s is empty.
+func MaxOf[T constraints.Number](s []T) optionals.Optional[T] {
+	return elementAt(s, ArgMax(s))
+}
+
+// Returns the index of the smallest element of s, or None if s is empty. Ties
+// are broken in favour of the first such element.
+func ArgMin[T constraints.Number](s []T) optionals.Optional[int] {
+	return argBest(s, func(x T) T { return x }, func(x, y T) bool { return x < y })
+}
+
+// Returns the index of the largest element of s, or None if s is empty. Ties
+// are broken in favour of the first such element.
+func ArgMax[T constraints.Number](s []T) optionals.Optional[int] {
+	return argBest(s, func(x T) T { return x }, func(x, y T) bool { return x > y })
+}
+
+// Returns the element of s for which key returns the smallest value, or None if
+// s is empty. Ties are broken in favour of the first such element. Calls key
+// once per element.
+func MinBy[T any, K constraints.Number](s []T, key func(T) K) optionals.Optional[T] {
+	return elementAt(s, argBest(s, key, func(x, y K) bool { return x < y }))
+}
+
+// Returns the element of s for which key returns the largest value, or None if
+// s is empty. Ties are broken in favour of the first such element. Calls key
+// once per element.
+func MaxBy[T any, K constraints.Number](s []T, key func(T) K) optionals.Optional[T] {
+	return elementAt(s, argBest(s, key, func(x, y K) bool { return x > y }))
+}
+
+// Returns the element of s at the given index, if any.
+func elementAt[T any](s []T, index optionals.Optional[int]) optionals.Optional[T] {
+	if i, exists := index.Get(); exists {
+		return optionals.Some(s[i])
+	}
+	return optionals.None[T]()
+}
+
+// Returns the index of the first element of s whose key is better than every
+// other element's key.
+func argBest[T any, K constraints.Number](s []T, key func(T) K, better func(x, y K) bool) optionals.Optional[int] {
+	if len(s) == 0 {
+		return optionals.None[int]()
+	}
+
+	best, bestKey := 0, key(s[0])
+	for i := 1; i < len(s); i++ {
+		if k := key(s[i]); better(k, bestKey) {
+			best, bestKey = i, k
+		}
+	}
+	return optionals.Some(best)
+}
@@ -0,0 +1,102 @@
+package slices
+
+import (
+	"math"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, 0, Sum([]int(nil)))
+	assert.Equal(t, 6, Sum([]int{1, 2, 3}))
+	assert.Equal(t, uint8(255), Sum([]uint8{200, 55}))
+	assert.Equal(t, 0.6, Sum([]float64{0.1, 0.2, 0.3}))
+
+	// Naive summation loses the small terms entirely.
+	values := []float64{1e100, 1, -1e100, 1}
+	assert.Equal(t, 2.0, Sum(values))
+
+	// Many small terms accumulate no rounding error.
+	tenths := make([]float64, 1000000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	assert.Equal(t, 100000.0, Sum(tenths))
+
+	assert.Equal(t, float32(0.6), Sum([]float32{0.1, 0.2, 0.3}))
+}
+
+func TestSumNonFinite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"infinity", []float64{math.Inf(1), 1}, math.Inf(1)},
+		{"negative infinity", []float64{1, math.Inf(-1), 2}, math.Inf(-1)},
+		{"overflow", []float64{math.MaxFloat64, math.MaxFloat64}, math.Inf(1)},
+		{"negative overflow", []float64{-math.MaxFloat64, -math.MaxFloat64, 1}, math.Inf(-1)},
+		{"infinity then overflow", []float64{math.Inf(1), math.MaxFloat64, math.MaxFloat64}, math.Inf(1)},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, Sum(tc.values), tc.name)
+		assert.Equal(t, optionals.Some(tc.expected/float64(len(tc.values))), Mean(tc.values), tc.name)
+	}
+
+	assert.True(t, math.IsNaN(Sum([]float64{1, math.NaN(), 2})), "NaN")
+	assert.True(t, math.IsNaN(Sum([]float64{math.Inf(1), math.Inf(-1)})), "opposite infinities")
+	assert.True(t, math.IsNaN(Mean([]float64{math.NaN()}).GetOrDefault(0)), "NaN mean")
+	assert.Equal(t, float32(math.Inf(1)), Sum([]float32{math.MaxFloat32, math.MaxFloat32}), "float32 overflow")
+}
+
+func TestProduct(t *testing.T) {
+	assert.Equal(t, 1, Product([]int(nil)))
+	assert.Equal(t, 24, Product([]int{1, 2, 3, 4}))
+	assert.Equal(t, 0.5, Product([]float64{0.25, 2}))
+}
+
+func TestMeanAndMedian(t *testing.T) {
+	assert.Equal(t, optionals.None[float64](), Mean([]int{}))
+	assert.Equal(t, optionals.Some(2.5), Mean([]int{1, 2, 3, 4}))
+	assert.InDelta(t, 0.2, Mean([]float64{0.1, 0.2, 0.3}).GetOrDefault(0), 1e-15)
+
+	assert.Equal(t, optionals.None[float64](), Median([]int(nil)))
+	assert.Equal(t, optionals.Some(3.0), Median([]int{5, 1, 3}))
+	assert.Equal(t, optionals.Some(2.5), Median([]int{4, 1, 3, 2}))
+
+	// The input is not modified.
+	input := []int{3, 1, 2}
+	Median(input)
+	assert.Equal(t, []int{3, 1, 2}, input)
+}
+
+func TestMode(t *testing.T) {
+	assert.Equal(t, optionals.None[string](), Mode([]string{}))
+	assert.Equal(t, optionals.Some("b"), Mode([]string{"a", "b", "c", "b"}))
+
+	// Ties go to the first element.
+	assert.Equal(t, optionals.Some(3), Mode([]int{3, 1, 1, 3}))
+}
+
+func TestMinMax(t *testing.T) {
+	assert.Equal(t, optionals.None[int](), MinOf([]int{}))
+	assert.Equal(t, optionals.None[int](), MaxOf([]int(nil)))
+	assert.Equal(t, optionals.Some(-2), MinOf([]int{3, -2, 7, -2}))
+	assert.Equal(t, optionals.Some(7.5), MaxOf([]float64{3, -2, 7.5}))
+
+	assert.Equal(t, optionals.None[int](), ArgMin([]int{}))
+	assert.Equal(t, optionals.Some(1), ArgMin([]int{3, -2, 7, -2}))
+	assert.Equal(t, optionals.Some(0), ArgMax([]int{7, -2, 7}))
+
+	type endpoint struct {
+		path    string
+		latency float64
+	}
+	endpoints := []endpoint{{"/a", 10}, {"/b", 2}, {"/c", 30}, {"/d", 2}}
+	latency := func(e endpoint) float64 { return e.latency }
+	assert.Equal(t, optionals.Some(endpoint{"/b", 2}), MinBy(endpoints, latency))
+	assert.Equal(t, optionals.Some(endpoint{"/c", 30}), MaxBy(endpoints, latency))
+	assert.Equal(t, optionals.None[endpoint](), MinBy(nil, latency))
+}
@@ -0,0 +1,43 @@
+package slices
+
+import "github.com/akitasoftware/go-utils/optionals"
+
+// Like Map, but appends the results to dst and returns the extended slice, as
+// the built-in append does. Reusing a buffer with enough capacity avoids
+// allocating.
+func AppendMap[T1, T2 any](dst []T2, slice []T1, f func(T1) T2) []T2 {
+	for _, t := range slice {
+		dst = append(dst, f(t))
+	}
+	return dst
+}
+
+// Like Filter, but appends the results to dst and returns the extended slice.
+func AppendFilter[T any](dst []T, slice []T, f func(T) bool) []T {
+	for _, t := range slice {
+		if f(t) {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
+
+// Like FilterMap, but appends the results to dst and returns the extended
+// slice.
+func AppendFilterMap[T1, T2 any](dst []T2, slice []T1, f func(T1) optionals.Optional[T2]) []T2 {
+	for _, t := range slice {
+		if u, exists := f(t).Get(); exists {
+			dst = append(dst, u)
+		}
+	}
+	return dst
+}
+
+// Like Reverse, but appends the results to dst and returns the extended slice.
+// dst must not overlap slice.
+func AppendReverse[T any](dst []T, slice []T) []T {
+	for i := len(slice) - 1; i >= 0; i-- {
+		dst = append(dst, slice[i])
+	}
+	return dst
+}
@@ -0,0 +1,57 @@
+package slices
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendVariants(t *testing.T) {
+	dst := []string{"x"}
+	assert.Equal(t, []string{"x", "1", "2"}, AppendMap(dst, []int{1, 2}, strconv.Itoa))
+	assert.Nil(t, AppendMap(nil, []int(nil), strconv.Itoa))
+
+	assert.Equal(t, []int{0, 2, 4}, AppendFilter([]int{0}, []int{1, 2, 3, 4}, isEven))
+
+	parse := func(s string) optionals.Optional[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return optionals.None[int]()
+		}
+		return optionals.Some(n)
+	}
+	assert.Equal(t, []int{1, 3}, AppendFilterMap(nil, []string{"1", "x", "3"}, parse))
+
+	assert.Equal(t, []int{9, 3, 2, 1}, AppendReverse([]int{9}, []int{1, 2, 3}))
+}
+
+func TestAppendAllocations(t *testing.T) {
+	input := make([]int, 1000)
+	buf := make([]int, 0, len(input))
+
+	testCases := map[string]func(){
+		"AppendMap":    func() { buf = AppendMap(buf[:0], input, func(x int) int { return x + 1 }) },
+		"AppendFilter": func() { buf = AppendFilter(buf[:0], input, isEven) },
+		"AppendFilterMap": func() {
+			buf = AppendFilterMap(buf[:0], input, func(x int) optionals.Optional[int] { return optionals.Some(x) })
+		},
+		"AppendReverse": func() { buf = AppendReverse(buf[:0], input) },
+	}
+
+	for name, f := range testCases {
+		assert.Equal(t, 0.0, testing.AllocsPerRun(100, f), name)
+	}
+}
+
+func BenchmarkAppendMap(b *testing.B) {
+	input := make([]int, 100000)
+	buf := make([]int, 0, len(input))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = AppendMap(buf[:0], input, func(x int) int { return x + 1 })
+	}
+}
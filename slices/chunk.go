@@ -0,0 +1,53 @@
+package slices
+
+// Splits s into consecutive chunks of the given size. The last chunk is
+// shorter if len(s) is not a multiple of size. The chunks share s's backing
+// array, but appending to a chunk never overwrites the next one. Returns nil if
+// s is nil. Panics if size is not positive.
+func Chunk[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		panic("slices: chunk size must be positive")
+	}
+
+	// Avoid creating an empty list if s is nil.
+	if s == nil {
+		return nil
+	}
+
+	rv := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := start + size
+		if end > len(s) {
+			end = len(s)
+		}
+		rv = append(rv, s[start:end:end])
+	}
+	return rv
+}
+
+// Returns the windows of the given size over s, starting at every step-th
+// element. Every window has exactly size elements, so if len(s) < size, there
+// are none. Like Chunk, the windows share s's backing array. Returns nil if s
+// is nil. Panics if size or step is not positive.
+func SlidingWindow[T any](s []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 {
+		panic("slices: window size and step must be positive")
+	}
+
+	// Avoid creating an empty list if s is nil.
+	if s == nil {
+		return nil
+	}
+
+	numWindows := 0
+	if len(s) >= size {
+		numWindows = (len(s)-size)/step + 1
+	}
+
+	rv := make([][]T, 0, numWindows)
+	for start := 0; start+size <= len(s); start += step {
+		end := start + size
+		rv = append(rv, s[start:end:end])
+	}
+	return rv
+}
@@ -0,0 +1,106 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		name     string
+		slice    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name: "nil",
+			size: 2,
+		},
+		{
+			name:     "empty",
+			slice:    []int{},
+			size:     2,
+			expected: [][]int{},
+		},
+		{
+			name:     "exact",
+			slice:    []int{1, 2, 3, 4},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "remainder",
+			slice:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:     "larger than slice",
+			slice:    []int{1, 2},
+			size:     5,
+			expected: [][]int{{1, 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, Chunk(tc.slice, tc.size), tc.name)
+	}
+
+	// Appending to a chunk doesn't clobber the next one.
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 99)
+	assert.Equal(t, []int{3, 4}, chunks[1])
+
+	assert.Panics(t, func() { Chunk(s, 0) })
+}
+
+func TestSlidingWindow(t *testing.T) {
+	testCases := []struct {
+		name       string
+		slice      []int
+		size, step int
+		expected   [][]int
+	}{
+		{
+			name: "nil",
+			size: 2,
+			step: 1,
+		},
+		{
+			name:     "step 1",
+			slice:    []int{1, 2, 3, 4},
+			size:     2,
+			step:     1,
+			expected: [][]int{{1, 2}, {2, 3}, {3, 4}},
+		},
+		{
+			name:     "step 2",
+			slice:    []int{1, 2, 3, 4, 5},
+			size:     3,
+			step:     2,
+			expected: [][]int{{1, 2, 3}, {3, 4, 5}},
+		},
+		{
+			name:     "step larger than size",
+			slice:    []int{1, 2, 3, 4, 5, 6},
+			size:     1,
+			step:     4,
+			expected: [][]int{{1}, {5}},
+		},
+		{
+			name:     "too short",
+			slice:    []int{1, 2},
+			size:     3,
+			step:     1,
+			expected: [][]int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, SlidingWindow(tc.slice, tc.size, tc.step), tc.name)
+	}
+
+	assert.Panics(t, func() { SlidingWindow([]int{1}, 1, 0) })
+}
@@ -0,0 +1,83 @@
+package slices
+
+import (
+	"github.com/akitasoftware/go-utils/optionals"
+	go_constraints "golang.org/x/exp/constraints"
+)
+
+// Compares two values, returning a negative number if a sorts before b, a
+// positive number if a sorts after b, and zero if they are equivalent.
+type Comparator[T any] func(a, b T) int
+
+// Returns a comparator that orders values by their natural order.
+func Natural[T go_constraints.Ordered]() Comparator[T] {
+	return func(a, b T) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+}
+
+// Returns a comparator that orders values by the given key, in the key's
+// natural order.
+func CompareBy[T any, K go_constraints.Ordered](key func(T) K) Comparator[T] {
+	return Comparing(key, Natural[K]())
+}
+
+// Returns a comparator that orders values by the given key, using the given
+// comparator for keys.
+func Comparing[T, K any](key func(T) K, cmp Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return cmp(key(a), key(b))
+	}
+}
+
+// Returns a comparator that orders values using c, breaking ties using next.
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if rv := c(a, b); rv != 0 {
+			return rv
+		}
+		return next(a, b)
+	}
+}
+
+// Returns a comparator that reverses the order of c.
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Returns a comparator for optional values that orders None before all other
+// values, and otherwise orders values using c.
+func NilsFirst[T any](c Comparator[T]) Comparator[optionals.Optional[T]] {
+	return compareOptionals(c, -1)
+}
+
+// Returns a comparator for optional values that orders None after all other
+// values, and otherwise orders values using c.
+func NilsLast[T any](c Comparator[T]) Comparator[optionals.Optional[T]] {
+	return compareOptionals(c, 1)
+}
+
+// noneOrder is the result of comparing None with a value that is present.
+func compareOptionals[T any](c Comparator[T], noneOrder int) Comparator[optionals.Optional[T]] {
+	return func(a, b optionals.Optional[T]) int {
+		aValue, aExists := a.Get()
+		bValue, bExists := b.Get()
+		switch {
+		case aExists && bExists:
+			return c(aValue, bValue)
+		case aExists:
+			return -noneOrder
+		case bExists:
+			return noneOrder
+		}
+		return 0
+	}
+}
@@ -0,0 +1,44 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+type person struct {
+	name string
+	age  optionals.Optional[int]
+}
+
+func TestComparator(t *testing.T) {
+	people := []person{
+		{"carol", optionals.Some(30)},
+		{"alice", optionals.None[int]()},
+		{"bob", optionals.Some(25)},
+		{"dave", optionals.Some(30)},
+		{"erin", optionals.None[int]()},
+	}
+	byAge := Comparing(func(p person) optionals.Optional[int] { return p.age }, NilsFirst(Natural[int]()))
+	byName := CompareBy(func(p person) string { return p.name })
+
+	names := func(ps []person) []string {
+		return Map(ps, func(p person) string { return p.name })
+	}
+
+	assert.Equal(t,
+		[]string{"alice", "erin", "bob", "carol", "dave"},
+		names(SortBy(people, byAge.ThenBy(byName))))
+	assert.Equal(t,
+		[]string{"erin", "alice", "bob", "dave", "carol"},
+		names(SortBy(people, byAge.ThenBy(byName.Reversed()))))
+	assert.Equal(t,
+		[]string{"carol", "dave", "bob", "alice", "erin"},
+		names(SortStableBy(people, byAge.Reversed())))
+
+	byAgeNilsLast := Comparing(func(p person) optionals.Optional[int] { return p.age }, NilsLast(Natural[int]()))
+	assert.Equal(t,
+		[]string{"bob", "carol", "dave", "alice", "erin"},
+		names(SortStableBy(people, byAgeNilsLast)))
+}
@@ -3,7 +3,8 @@ package slices
 import "github.com/akitasoftware/go-utils/optionals"
 
 // Applies f to each element of slice in order, removes any None results, and
-// returns the rest.
+// returns the rest. Because f returns an Optional, which holds its value by
+// pointer, this allocates once for each Some result; Map and Filter don't.
 func FilterMap[T1, T2 any](slice []T1, f func(T1) optionals.Optional[T2]) []T2 {
 	result, _ := FilterMapIndexWithErr(slice, func(_ int, t T1) (optionals.Optional[T2], error) {
 		return f(t), nil
@@ -30,6 +31,16 @@ func FilterMapIndex[T1, T2 any](slice []T1, f func(int, T1) optionals.Optional[T
 
 // Like FilterMapWithErr, but f also takes in the element's index.
 func FilterMapIndexWithErr[T1, T2 any](slice []T1, f func(int, T1) (optionals.Optional[T2], error)) ([]T2, error) {
+	return filterMapValues(slice, func(idx int, t T1) (optionals.Value[T2], error) {
+		u, err := f(idx, t)
+		return optionals.ToValue(u), err
+	})
+}
+
+// Implements the FilterMap, Filter and Map families. Taking an inline
+// optionals.Value rather than an Optional means callers that construct their
+// results here, like Map and Filter, don't allocate for each element.
+func filterMapValues[T1, T2 any](slice []T1, f func(int, T1) (optionals.Value[T2], error)) ([]T2, error) {
 	if slice == nil {
 		return nil, nil
 	}
@@ -0,0 +1,63 @@
+package slices
+
+// Concatenates the given slices into a new slice. Returns nil if ss is nil.
+func Flatten[T any](ss [][]T) []T {
+	// Avoid creating an empty list if ss is nil.
+	if ss == nil {
+		return nil
+	}
+
+	total := 0
+	for _, s := range ss {
+		total += len(s)
+	}
+
+	rv := make([]T, 0, total)
+	for _, s := range ss {
+		rv = append(rv, s...)
+	}
+	return rv
+}
+
+// Applies f to each element of slice in order, and concatenates the results.
+func FlatMap[T1, T2 any](slice []T1, f func(T1) []T2) []T2 {
+	result, _ := FlatMapIndexWithErr(slice, func(_ int, t T1) ([]T2, error) {
+		return f(t), nil
+	})
+	return result
+}
+
+// Applies f to each element of slice in order, and concatenates the results.
+// If f returns a non-nil error on any element, iteration immediately stops,
+// and the error is returned.
+func FlatMapWithErr[T1, T2 any](slice []T1, f func(T1) ([]T2, error)) ([]T2, error) {
+	return FlatMapIndexWithErr(slice, func(_ int, t T1) ([]T2, error) {
+		return f(t)
+	})
+}
+
+// Like FlatMap, but f also takes in the element's index.
+func FlatMapIndex[T1, T2 any](slice []T1, f func(int, T1) []T2) []T2 {
+	result, _ := FlatMapIndexWithErr(slice, func(idx int, t T1) ([]T2, error) {
+		return f(idx, t), nil
+	})
+	return result
+}
+
+// Like FlatMapWithErr, but f also takes in the element's index.
+func FlatMapIndexWithErr[T1, T2 any](slice []T1, f func(int, T1) ([]T2, error)) ([]T2, error) {
+	if slice == nil {
+		return nil, nil
+	}
+
+	result := make([]T2, 0, len(slice))
+	for idx, t := range slice {
+		ts, err := f(idx, t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ts...)
+	}
+
+	return result, nil
+}
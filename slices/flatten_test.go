@@ -0,0 +1,48 @@
+package slices
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	assert.Nil(t, Flatten([][]int(nil)))
+	assert.Equal(t, []int{}, Flatten([][]int{nil, {}}))
+	assert.Equal(t, []int{1, 2, 3}, Flatten([][]int{{1}, nil, {2, 3}}))
+}
+
+func TestFlatMap(t *testing.T) {
+	assert.Nil(t, FlatMap(nil, strings.Fields))
+	assert.Equal(t,
+		[]string{"a", "b", "c"},
+		FlatMap([]string{"a b", "", "c"}, strings.Fields))
+	assert.Equal(t,
+		[]string{"0", "a", "1", "b"},
+		FlatMapIndex([]string{"a", "b"}, func(i int, s string) []string { return []string{strconv.Itoa(i), s} }))
+
+	repeat := func(i int, s string) ([]string, error) {
+		if i < 0 {
+			return nil, errors.New("negative")
+		}
+		return []string{s, s}, nil
+	}
+	result, err := FlatMapIndexWithErr([]string{"a", "b"}, repeat)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "a", "b", "b"}, result)
+
+	parse := func(s string) ([]int, error) {
+		n, err := strconv.Atoi(s)
+		return []int{n}, err
+	}
+	ints, err := FlatMapWithErr([]string{"1", "2"}, parse)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ints)
+
+	ints, err = FlatMapWithErr([]string{"1", "x"}, parse)
+	assert.Error(t, err)
+	assert.Nil(t, ints)
+}
@@ -0,0 +1,122 @@
+package slices
+
+import "github.com/akitasoftware/go-utils/optionals"
+
+// Combines the elements of slice from left to right, starting with the
+// accumulator init and replacing it with f(accumulator, element) for each
+// element. Returns init if slice is empty.
+func Fold[T, A any](slice []T, init A, f func(A, T) A) A {
+	result, _ := FoldIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t), nil
+	})
+	return result
+}
+
+// Like Fold, but if f returns a non-nil error on any element, iteration
+// immediately stops, and the error is returned.
+func FoldWithErr[T, A any](slice []T, init A, f func(A, T) (A, error)) (A, error) {
+	return FoldIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t)
+	})
+}
+
+// Like Fold, but f also takes in the element's index.
+func FoldIndex[T, A any](slice []T, init A, f func(A, int, T) A) A {
+	result, _ := FoldIndexWithErr(slice, init, func(acc A, idx int, t T) (A, error) {
+		return f(acc, idx, t), nil
+	})
+	return result
+}
+
+// Like FoldWithErr, but f also takes in the element's index. On error, the
+// zero value of A is returned.
+func FoldIndexWithErr[T, A any](slice []T, init A, f func(A, int, T) (A, error)) (A, error) {
+	acc := init
+	for idx, t := range slice {
+		var err error
+		if acc, err = f(acc, idx, t); err != nil {
+			var zero A
+			return zero, err
+		}
+	}
+	return acc, nil
+}
+
+// Like Fold, but combines the elements from right to left.
+func FoldRight[T, A any](slice []T, init A, f func(A, T) A) A {
+	result, _ := FoldRightIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t), nil
+	})
+	return result
+}
+
+// Like FoldWithErr, but combines the elements from right to left.
+func FoldRightWithErr[T, A any](slice []T, init A, f func(A, T) (A, error)) (A, error) {
+	return FoldRightIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t)
+	})
+}
+
+// Like FoldRight, but f also takes in the element's index.
+func FoldRightIndex[T, A any](slice []T, init A, f func(A, int, T) A) A {
+	result, _ := FoldRightIndexWithErr(slice, init, func(acc A, idx int, t T) (A, error) {
+		return f(acc, idx, t), nil
+	})
+	return result
+}
+
+// Like FoldRightWithErr, but f also takes in the element's index.
+func FoldRightIndexWithErr[T, A any](slice []T, init A, f func(A, int, T) (A, error)) (A, error) {
+	acc := init
+	for idx := len(slice) - 1; idx >= 0; idx-- {
+		var err error
+		if acc, err = f(acc, idx, slice[idx]); err != nil {
+			var zero A
+			return zero, err
+		}
+	}
+	return acc, nil
+}
+
+// Like Fold, but uses the first element as the initial accumulator. Returns
+// None if slice is empty.
+func Reduce[T any](slice []T, f func(T, T) T) optionals.Optional[T] {
+	result, _ := ReduceIndexWithErr(slice, func(acc T, _ int, t T) (T, error) {
+		return f(acc, t), nil
+	})
+	return result
+}
+
+// Like Reduce, but if f returns a non-nil error on any element, iteration
+// immediately stops, and the error is returned.
+func ReduceWithErr[T any](slice []T, f func(T, T) (T, error)) (optionals.Optional[T], error) {
+	return ReduceIndexWithErr(slice, func(acc T, _ int, t T) (T, error) {
+		return f(acc, t)
+	})
+}
+
+// Like Reduce, but f also takes in the index of the element being combined
+// into the accumulator, starting from 1.
+func ReduceIndex[T any](slice []T, f func(T, int, T) T) optionals.Optional[T] {
+	result, _ := ReduceIndexWithErr(slice, func(acc T, idx int, t T) (T, error) {
+		return f(acc, idx, t), nil
+	})
+	return result
+}
+
+// Like ReduceWithErr, but f also takes in the index of the element being
+// combined into the accumulator, starting from 1.
+func ReduceIndexWithErr[T any](slice []T, f func(T, int, T) (T, error)) (optionals.Optional[T], error) {
+	if len(slice) == 0 {
+		return optionals.None[T](), nil
+	}
+
+	acc := slice[0]
+	for idx := 1; idx < len(slice); idx++ {
+		var err error
+		if acc, err = f(acc, idx, slice[idx]); err != nil {
+			return optionals.None[T](), err
+		}
+	}
+	return optionals.Some(acc), nil
+}
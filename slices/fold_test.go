@@ -0,0 +1,93 @@
+package slices
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func concat(acc string, s string) string {
+	return acc + s
+}
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "init", Fold(nil, "init", concat))
+	assert.Equal(t, ">abc", Fold([]string{"a", "b", "c"}, ">", concat))
+	assert.Equal(t, ">cba", FoldRight([]string{"a", "b", "c"}, ">", concat))
+
+	withIndex := func(acc string, idx int, s string) string {
+		return acc + fmt.Sprintf("%d%s", idx, s)
+	}
+	assert.Equal(t, "0a1b", FoldIndex([]string{"a", "b"}, "", withIndex))
+	assert.Equal(t, "1b0a", FoldRightIndex([]string{"a", "b"}, "", withIndex))
+}
+
+func TestFoldWithErr(t *testing.T) {
+	sumInts := func(acc int, s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		return acc + n, err
+	}
+
+	sum, err := FoldWithErr([]string{"1", "2", "3"}, 0, sumInts)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, sum)
+
+	sum, err = FoldRightWithErr([]string{"1", "2", "3"}, 10, sumInts)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, sum)
+
+	// Iteration stops at the first error.
+	calls := 0
+	failAt := func(acc int, idx int, s string) (int, error) {
+		calls++
+		if idx == 1 {
+			return 0, errors.New("test error path")
+		}
+		return acc + 1, nil
+	}
+	_, err = FoldIndexWithErr([]string{"a", "b", "c"}, 0, failAt)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	_, err = FoldRightIndexWithErr([]string{"a", "b", "c"}, 0, failAt)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestReduce(t *testing.T) {
+	add := func(x, y int) int { return x + y }
+	assert.Equal(t, optionals.None[int](), Reduce(nil, add))
+	assert.Equal(t, optionals.Some(1), Reduce([]int{1}, add))
+	assert.Equal(t, optionals.Some(6), Reduce([]int{1, 2, 3}, add))
+
+	// Indices start at 1, since the first element is the initial accumulator.
+	indices := []int{}
+	ReduceIndex([]int{1, 2, 3}, func(acc int, idx int, x int) int {
+		indices = append(indices, idx)
+		return acc + x
+	})
+	assert.Equal(t, []int{1, 2}, indices)
+
+	divide := func(x, y int) (int, error) {
+		if y == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return x / y, nil
+	}
+	result, err := ReduceWithErr([]int{100, 5, 2}, divide)
+	assert.NoError(t, err)
+	assert.Equal(t, optionals.Some(10), result)
+
+	result, err = ReduceWithErr([]int{100, 0, 2}, divide)
+	assert.Error(t, err)
+	assert.Equal(t, optionals.None[int](), result)
+
+	result, err = ReduceIndexWithErr([]int{}, func(acc int, _ int, x int) (int, error) { return divide(acc, x) })
+	assert.NoError(t, err)
+	assert.Equal(t, optionals.None[int](), result)
+}
@@ -0,0 +1,70 @@
+package slices
+
+import "github.com/akitasoftware/go-utils/optionals"
+
+// Calls f on each element of slice in order.
+func ForEach[T any](slice []T, f func(T)) {
+	for _, t := range slice {
+		f(t)
+	}
+}
+
+// Calls f on each element of slice in order. If f returns a non-nil error on
+// any element, iteration immediately stops, and the error is returned.
+func ForEachWithErr[T any](slice []T, f func(T) error) error {
+	return ForEachIndexWithErr(slice, func(_ int, t T) error {
+		return f(t)
+	})
+}
+
+// Like ForEach, but f also takes in the element's index.
+func ForEachIndex[T any](slice []T, f func(int, T)) {
+	for idx, t := range slice {
+		f(idx, t)
+	}
+}
+
+// Like ForEachWithErr, but f also takes in the element's index.
+func ForEachIndexWithErr[T any](slice []T, f func(int, T) error) error {
+	for idx, t := range slice {
+		if err := f(idx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns true if every element of slice satisfies the predicate f, including
+// when slice is empty. Stops at the first element that doesn't.
+func All[T any](slice []T, f func(T) bool) bool {
+	return FindIndex(slice, func(t T) bool { return !f(t) }).IsNone()
+}
+
+// Returns true if any element of slice satisfies the predicate f. Stops at the
+// first element that does.
+func Any[T any](slice []T, f func(T) bool) bool {
+	return FindIndex(slice, f).IsSome()
+}
+
+// Returns true if no element of slice satisfies the predicate f, including
+// when slice is empty. Stops at the first element that does.
+func None[T any](slice []T, f func(T) bool) bool {
+	return FindIndex(slice, f).IsNone()
+}
+
+// Returns the first element of slice that satisfies the predicate f, or None
+// if there is no such element.
+func Find[T any](slice []T, f func(T) bool) optionals.Optional[T] {
+	return elementAt(slice, FindIndex(slice, f))
+}
+
+// Returns the index of the first element of slice that satisfies the predicate
+// f, or None if there is no such element.
+func FindIndex[T any](slice []T, f func(T) bool) optionals.Optional[int] {
+	for idx, t := range slice {
+		if f(t) {
+			return optionals.Some(idx)
+		}
+	}
+	return optionals.None[int]()
+}
@@ -0,0 +1,83 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	var visited []int
+	ForEach([]int{1, 2, 3}, func(x int) { visited = append(visited, x) })
+	assert.Equal(t, []int{1, 2, 3}, visited)
+
+	visited = nil
+	ForEachIndex([]int{5, 6}, func(idx, _ int) { visited = append(visited, idx) })
+	assert.Equal(t, []int{0, 1}, visited)
+
+	visited = nil
+	err := ForEachWithErr([]int{1, 2, 3}, func(x int) error {
+		visited = append(visited, x)
+		if x == 2 {
+			return errors.New("test error path")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []int{1, 2}, visited)
+
+	assert.NoError(t, ForEachIndexWithErr([]int{1}, func(int, int) error { return nil }))
+}
+
+func TestPredicates(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	testCases := []struct {
+		name                 string
+		slice                []int
+		expectAll, expectAny bool
+		expectNone           bool
+		expectFind           optionals.Optional[int]
+		expectFindIndex      optionals.Optional[int]
+	}{
+		{
+			name:            "nil",
+			expectAll:       true,
+			expectNone:      true,
+			expectFind:      optionals.None[int](),
+			expectFindIndex: optionals.None[int](),
+		},
+		{
+			name:            "all even",
+			slice:           []int{2, 4},
+			expectAll:       true,
+			expectAny:       true,
+			expectFind:      optionals.Some(2),
+			expectFindIndex: optionals.Some(0),
+		},
+		{
+			name:            "some even",
+			slice:           []int{1, 3, 4, 6},
+			expectAny:       true,
+			expectFind:      optionals.Some(4),
+			expectFindIndex: optionals.Some(2),
+		},
+		{
+			name:            "none even",
+			slice:           []int{1, 3},
+			expectNone:      true,
+			expectFind:      optionals.None[int](),
+			expectFindIndex: optionals.None[int](),
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectAll, All(tc.slice, isEven), tc.name)
+		assert.Equal(t, tc.expectAny, Any(tc.slice, isEven), tc.name)
+		assert.Equal(t, tc.expectNone, None(tc.slice, isEven), tc.name)
+		assert.Equal(t, tc.expectFind, Find(tc.slice, isEven), tc.name)
+		assert.Equal(t, tc.expectFindIndex, FindIndex(tc.slice, isEven), tc.name)
+	}
+}
@@ -0,0 +1,88 @@
+package slices
+
+import (
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/pkg/errors"
+)
+
+// Groups the elements of s by the given key. Within each group, elements keep
+// their order from s. Returns an empty map if s is empty.
+func GroupBy[T any, K comparable](s []T, key func(T) K) maps.Map[K, []T] {
+	rv := maps.NewMap[K, []T]()
+	for _, t := range s {
+		k := key(t)
+		rv[k] = append(rv[k], t)
+	}
+	return rv
+}
+
+// Splits s into the elements that satisfy the predicate f and those that
+// don't, preserving their order. Returns two nil slices if s is nil.
+func Partition[T any](s []T, f func(T) bool) (matching []T, rest []T) {
+	if s == nil {
+		return nil, nil
+	}
+
+	matching = make([]T, 0, len(s))
+	rest = make([]T, 0, len(s))
+	for _, t := range s {
+		if f(t) {
+			matching = append(matching, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	return matching, rest
+}
+
+// Returns a map from the given key to the elements of s. If several elements
+// have the same key, onConflict is called, as with maps.Map.Upsert, with the
+// value so far and the later element, and its result is kept. See KeepFirst
+// and KeepLast.
+func KeyBy[T any, K comparable](s []T, key func(T) K, onConflict func(v, newV T) T) maps.Map[K, T] {
+	rv := maps.NewMap[K, T]()
+	for _, t := range s {
+		rv.Upsert(key(t), t, onConflict)
+	}
+	return rv
+}
+
+// Returns a map from the given key to the elements of s, which must have
+// distinct keys. Returns an error if two elements have the same key.
+func IndexBy[T any, K comparable](s []T, key func(T) K) (maps.Map[K, T], error) {
+	rv := maps.NewMap[K, T]()
+	for i, t := range s {
+		k := key(t)
+		if rv.ContainsKey(k) {
+			return nil, errors.Errorf("duplicate key %v at index %d", k, i)
+		}
+		rv.Put(k, t)
+	}
+	return rv, nil
+}
+
+// A conflict policy for KeyBy and maps.Map.Upsert that keeps the existing
+// value.
+func KeepFirst[T any](v, newV T) T {
+	return v
+}
+
+// A conflict policy for KeyBy and maps.Map.Upsert that keeps the new value.
+func KeepLast[T any](v, newV T) T {
+	return newV
+}
+
+// Returns the number of elements of s with each key.
+func CountBy[T any, K comparable](s []T, key func(T) K) maps.Map[K, int] {
+	rv := maps.NewMap[K, int]()
+	for _, t := range s {
+		rv[key(t)]++
+	}
+	return rv
+}
+
+// Returns a set of the elements of s.
+func ToSet[T comparable](s []T) sets.Set[T] {
+	return sets.NewSet(s...)
+}
@@ -0,0 +1,69 @@
+package slices
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+)
+
+func firstLetter(s string) string {
+	return s[:1]
+}
+
+func TestGroupBy(t *testing.T) {
+	assert.Equal(t, maps.NewMap[string, []string](), GroupBy(nil, firstLetter))
+	assert.Equal(t,
+		maps.Map[string, []string]{
+			"a": {"apple", "avocado"},
+			"b": {"banana"},
+		},
+		GroupBy([]string{"apple", "banana", "avocado"}, firstLetter))
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	matching, rest := Partition(nil, isEven)
+	assert.Nil(t, matching)
+	assert.Nil(t, rest)
+
+	matching, rest = Partition([]int{1, 2, 3, 4, 5}, isEven)
+	assert.Equal(t, []int{2, 4}, matching)
+	assert.Equal(t, []int{1, 3, 5}, rest)
+}
+
+func TestKeyBy(t *testing.T) {
+	input := []string{"apple", "banana", "avocado"}
+	assert.Equal(t,
+		maps.Map[string, string]{"a": "apple", "b": "banana"},
+		KeyBy(input, firstLetter, KeepFirst[string]))
+	assert.Equal(t,
+		maps.Map[string, string]{"a": "avocado", "b": "banana"},
+		KeyBy(input, firstLetter, KeepLast[string]))
+	assert.Equal(t,
+		maps.Map[string, string]{"a": "apple+avocado", "b": "banana"},
+		KeyBy(input, firstLetter, func(v, newV string) string { return v + "+" + newV }))
+}
+
+func TestIndexBy(t *testing.T) {
+	index, err := IndexBy([]string{"apple", "banana"}, firstLetter)
+	assert.NoError(t, err)
+	assert.Equal(t, maps.Map[string, string]{"a": "apple", "b": "banana"}, index)
+
+	_, err = IndexBy([]string{"apple", "banana", "avocado"}, firstLetter)
+	assert.Error(t, err)
+}
+
+func TestCountBy(t *testing.T) {
+	assert.Equal(t,
+		maps.Map[string, int]{"get": 2, "post": 1},
+		CountBy([]string{"GET", "POST", "get"}, strings.ToLower))
+}
+
+func TestToSet(t *testing.T) {
+	assert.Equal(t, sets.NewSet(1, 2, 3), ToSet([]int{1, 2, 3, 2}))
+	assert.Equal(t, sets.NewSet[int](), ToSet([]int(nil)))
+}
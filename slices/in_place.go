@@ -0,0 +1,41 @@
+package slices
+
+// Reverses the elements of s in place.
+func ReverseInPlace[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Removes the elements of s that don't satisfy the predicate f, in place,
+// preserving the order of the rest. Returns the shortened slice, which shares
+// s's backing array. The elements past the end of the result are zeroed, so
+// that they don't keep garbage alive.
+func FilterInPlace[T any](s []T, f func(T) bool) []T {
+	kept := 0
+	for _, t := range s {
+		if f(t) {
+			s[kept] = t
+			kept++
+		}
+	}
+
+	var zero T
+	for i := kept; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:kept]
+}
+
+// Removes the elements of s that satisfy the predicate f, in place. Like
+// FilterInPlace, returns the shortened slice and zeroes the rest.
+func RemoveIf[T any](s []T, f func(T) bool) []T {
+	return FilterInPlace(s, func(t T) bool { return !f(t) })
+}
+
+// Replaces each element of s with the result of applying f to it.
+func MapInPlace[T any](s []T, f func(T) T) {
+	for i, t := range s {
+		s[i] = f(t)
+	}
+}
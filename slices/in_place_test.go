@@ -0,0 +1,96 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(x int) bool {
+	return x%2 == 0
+}
+
+func TestReverseInPlace(t *testing.T) {
+	for _, tc := range [][2][]int{
+		{nil, nil},
+		{{1}, {1}},
+		{{1, 2}, {2, 1}},
+		{{1, 2, 3}, {3, 2, 1}},
+	} {
+		ReverseInPlace(tc[0])
+		assert.Equal(t, tc[1], tc[0])
+	}
+}
+
+func TestFilterInPlace(t *testing.T) {
+	assert.Empty(t, FilterInPlace([]int(nil), isEven))
+
+	s := []int{1, 2, 3, 4, 5, 6}
+	filtered := FilterInPlace(s, isEven)
+	assert.Equal(t, []int{2, 4, 6}, filtered)
+
+	// The tail is zeroed.
+	assert.Equal(t, []int{2, 4, 6, 0, 0, 0}, s)
+
+	// Pointers in the tail are cleared, so they can be collected.
+	x, y := 1, 2
+	ptrs := []*int{&x, &y}
+	kept := FilterInPlace(ptrs, func(p *int) bool { return *p == 2 })
+	assert.Equal(t, []*int{&y}, kept)
+	assert.Nil(t, ptrs[1])
+}
+
+func TestRemoveIf(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	assert.Equal(t, []int{1, 3}, RemoveIf(s, isEven))
+	assert.Equal(t, []int{1, 3, 0, 0}, s)
+}
+
+func TestMapInPlace(t *testing.T) {
+	s := []int{1, 2, 3}
+	MapInPlace(s, func(x int) int { return x * 10 })
+	assert.Equal(t, []int{10, 20, 30}, s)
+}
+
+func TestInPlaceAllocations(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+
+	testCases := map[string]func(){
+		"ReverseInPlace": func() { ReverseInPlace(input) },
+		"MapInPlace":     func() { MapInPlace(input, func(x int) int { return x ^ 1 }) },
+		"FilterInPlace": func() {
+			// Keeps everything, so that the input is unchanged across runs.
+			FilterInPlace(input, func(int) bool { return true })
+		},
+		"RemoveIf": func() { RemoveIf(input, func(int) bool { return false }) },
+	}
+
+	for name, f := range testCases {
+		assert.Equal(t, 0.0, testing.AllocsPerRun(100, f), name)
+	}
+}
+
+func BenchmarkFilterInPlace(b *testing.B) {
+	input := make([]int, 100000)
+	buf := make([]int, len(input))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, input)
+		FilterInPlace(buf, isEven)
+	}
+}
+
+func BenchmarkMapInPlace(b *testing.B) {
+	buf := make([]int, 100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapInPlace(buf, func(x int) int { return x + 1 })
+	}
+}
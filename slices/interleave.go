@@ -0,0 +1,31 @@
+package slices
+
+// Returns a new slice that takes one element from each of the given slices in
+// turn: the first element of each, then the second element of each, and so
+// on. Slices that run out are skipped. Returns nil if every input is nil.
+func Interleave[T any](ss ...[]T) []T {
+	total, longest := 0, 0
+	allNil := true
+	for _, s := range ss {
+		total += len(s)
+		if len(s) > longest {
+			longest = len(s)
+		}
+		allNil = allNil && s == nil
+	}
+
+	// Avoid creating an empty list if every input is nil.
+	if allNil {
+		return nil
+	}
+
+	rv := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, s := range ss {
+			if i < len(s) {
+				rv = append(rv, s[i])
+			}
+		}
+	}
+	return rv
+}
@@ -0,0 +1,16 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterleave(t *testing.T) {
+	assert.Nil(t, Interleave[int]())
+	assert.Nil(t, Interleave[int](nil, nil))
+	assert.Equal(t, []int{}, Interleave([]int{}, nil))
+	assert.Equal(t,
+		[]int{1, 10, 100, 2, 20, 3},
+		Interleave([]int{1, 2, 3}, []int{10, 20}, []int{100}))
+}
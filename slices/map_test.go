@@ -3,6 +3,7 @@ package slices
 import (
 	"testing"
 
+	"github.com/akitasoftware/go-utils/optionals"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -96,3 +97,43 @@ func TestMapWithErr(t *testing.T) {
 		}
 	}
 }
+
+func TestMapAllocations(t *testing.T) {
+	input := make([]int, 1000)
+	allocs := testing.AllocsPerRun(100, func() {
+		Map(input, func(x int) int { return x + 1 })
+	})
+
+	// Only the result slice should be allocated.
+	assert.Equal(t, 1.0, allocs)
+}
+
+func benchmarkInput() []int {
+	input := make([]int, 100000)
+	for i := range input {
+		input[i] = i
+	}
+	return input
+}
+
+func BenchmarkMap(b *testing.B) {
+	input := benchmarkInput()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Map(input, func(x int) int { return x + 1 })
+	}
+}
+
+// The same mapping as BenchmarkMap, through the Optional returned by
+// FilterMap's callback.
+func BenchmarkFilterMap(b *testing.B) {
+	input := benchmarkInput()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FilterMap(input, func(x int) optionals.Optional[int] { return optionals.Some(x + 1) })
+	}
+}
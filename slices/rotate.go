@@ -0,0 +1,23 @@
+package slices
+
+// Returns a new slice with the elements of s rotated left by k positions, so
+// that s[k] comes first. A negative k rotates right, and k may exceed len(s).
+// Returns nil if s is nil.
+func Rotate[T any](s []T, k int) []T {
+	// Avoid creating an empty list if s is nil.
+	if s == nil {
+		return nil
+	}
+
+	rv := make([]T, 0, len(s))
+	if len(s) == 0 {
+		return rv
+	}
+
+	k %= len(s)
+	if k < 0 {
+		k += len(s)
+	}
+	rv = append(rv, s[k:]...)
+	return append(rv, s[:k]...)
+}
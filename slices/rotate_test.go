@@ -0,0 +1,49 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		slice    []int
+		k        int
+		expected []int
+	}{
+		{
+			name: "nil",
+			k:    1,
+		},
+		{
+			name:     "empty",
+			slice:    []int{},
+			k:        3,
+			expected: []int{},
+		},
+		{
+			name:     "left",
+			slice:    []int{1, 2, 3, 4},
+			k:        1,
+			expected: []int{2, 3, 4, 1},
+		},
+		{
+			name:     "right",
+			slice:    []int{1, 2, 3, 4},
+			k:        -1,
+			expected: []int{4, 1, 2, 3},
+		},
+		{
+			name:     "wrap around",
+			slice:    []int{1, 2, 3, 4},
+			k:        6,
+			expected: []int{3, 4, 1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, Rotate(tc.slice, tc.k), tc.name)
+	}
+}
@@ -0,0 +1,38 @@
+package slices
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Returns a uniform random sample of min(k, len(s)) elements of s, in no
+// particular order. Returns nil if s is nil.
+func Sample[T any](s []T, k int) []T {
+	return SampleWithRand(s, k, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// Like Sample, but draws random numbers from rng, so that the sample is
+// reproducible.
+func SampleWithRand[T any](s []T, k int, rng *rand.Rand) []T {
+	// Avoid creating an empty list if s is nil.
+	if s == nil {
+		return nil
+	}
+
+	if k > len(s) {
+		k = len(s)
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// Reservoir sampling, so that s need not be copied.
+	rv := make([]T, k)
+	copy(rv, s[:k])
+	for i := k; i < len(s); i++ {
+		if j := rng.Intn(i + 1); j < k {
+			rv[j] = s[i]
+		}
+	}
+	return rv
+}
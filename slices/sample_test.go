@@ -0,0 +1,22 @@
+package slices
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSample(t *testing.T) {
+	assert.Nil(t, Sample([]int(nil), 3))
+	assert.Equal(t, []int{}, Sample([]int{1, 2}, 0))
+	assert.ElementsMatch(t, []int{1, 2}, Sample([]int{1, 2}, 5))
+
+	// Reproducible given the same source.
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	sample1 := SampleWithRand(input, 3, rand.New(rand.NewSource(1)))
+	sample2 := SampleWithRand(input, 3, rand.New(rand.NewSource(1)))
+	assert.Equal(t, sample1, sample2)
+	assert.Len(t, sample1, 3)
+	assert.Subset(t, input, sample1)
+}
@@ -0,0 +1,45 @@
+package slices
+
+// Like Fold, but returns every intermediate accumulator: the i-th result is
+// the fold of the first i+1 elements. The initial accumulator is not included,
+// so the result has the same length as slice. Returns nil if slice is nil.
+func Scan[T, A any](slice []T, init A, f func(A, T) A) []A {
+	result, _ := ScanIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t), nil
+	})
+	return result
+}
+
+// Like Scan, but if f returns a non-nil error on any element, iteration
+// immediately stops, and the error is returned.
+func ScanWithErr[T, A any](slice []T, init A, f func(A, T) (A, error)) ([]A, error) {
+	return ScanIndexWithErr(slice, init, func(acc A, _ int, t T) (A, error) {
+		return f(acc, t)
+	})
+}
+
+// Like Scan, but f also takes in the element's index.
+func ScanIndex[T, A any](slice []T, init A, f func(A, int, T) A) []A {
+	result, _ := ScanIndexWithErr(slice, init, func(acc A, idx int, t T) (A, error) {
+		return f(acc, idx, t), nil
+	})
+	return result
+}
+
+// Like ScanWithErr, but f also takes in the element's index.
+func ScanIndexWithErr[T, A any](slice []T, init A, f func(A, int, T) (A, error)) ([]A, error) {
+	if slice == nil {
+		return nil, nil
+	}
+
+	result := make([]A, 0, len(slice))
+	acc := init
+	for idx, t := range slice {
+		var err error
+		if acc, err = f(acc, idx, t); err != nil {
+			return nil, err
+		}
+		result = append(result, acc)
+	}
+	return result, nil
+}
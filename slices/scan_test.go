@@ -0,0 +1,32 @@
+package slices
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	add := func(acc, x int) int { return acc + x }
+	assert.Nil(t, Scan(nil, 0, add))
+	assert.Equal(t, []int{}, Scan([]int{}, 0, add))
+	assert.Equal(t, []int{1, 3, 6, 10}, Scan([]int{1, 2, 3, 4}, 0, add))
+	assert.Equal(t,
+		[]int{0, 1, 3},
+		ScanIndex([]int{5, 5, 5}, 0, func(acc, idx, _ int) int { return acc + idx }))
+
+	parseAndAdd := func(acc int, s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		return acc + n, err
+	}
+	result, err := ScanWithErr([]string{"1", "2"}, 10, parseAndAdd)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{11, 13}, result)
+
+	result, err = ScanIndexWithErr([]string{"1", "x"}, 10, func(acc int, _ int, s string) (int, error) {
+		return parseAndAdd(acc, s)
+	})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
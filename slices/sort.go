@@ -0,0 +1,175 @@
+package slices
+
+import (
+	"container/heap"
+	"sort"
+
+	go_constraints "golang.org/x/exp/constraints"
+)
+
+// Returns a new slice with the elements of s sorted by cmp. The sort is not
+// guaranteed to be stable. Returns nil if s is nil.
+func SortBy[T any](s []T, cmp Comparator[T]) []T {
+	if s == nil {
+		return nil
+	}
+	rv := append(make([]T, 0, len(s)), s...)
+	sort.Slice(rv, func(i, j int) bool { return cmp(rv[i], rv[j]) < 0 })
+	return rv
+}
+
+// Like SortBy, but equivalent elements keep their relative order from s.
+func SortStableBy[T any](s []T, cmp Comparator[T]) []T {
+	if s == nil {
+		return nil
+	}
+	rv := append(make([]T, 0, len(s)), s...)
+	sort.SliceStable(rv, func(i, j int) bool { return cmp(rv[i], rv[j]) < 0 })
+	return rv
+}
+
+// Returns a new slice with the elements of s stably sorted by the given key.
+// Calls key once per element. Returns nil if s is nil.
+func SortedByKey[T any, K go_constraints.Ordered](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+
+	// Compute each key once, and sort the elements alongside their keys.
+	type keyed struct {
+		key K
+		elt T
+	}
+	pairs := make([]keyed, len(s))
+	for i, t := range s {
+		pairs[i] = keyed{key: key(t), elt: t}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+
+	rv := make([]T, len(s))
+	for i, p := range pairs {
+		rv[i] = p.elt
+	}
+	return rv
+}
+
+// Returns true if s is sorted according to cmp.
+func IsSortedBy[T any](s []T, cmp Comparator[T]) bool {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i-1], s[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Searches s, which must be sorted consistently with cmp, for target. cmp
+// compares an element of s with the target. Returns the index of the first
+// element that does not sort before target, and whether that element is
+// equivalent to target. If no such element exists, returns len(s).
+func BinarySearchBy[T, K any](s []T, target K, cmp func(T, K) int) (int, bool) {
+	i := sort.Search(len(s), func(i int) bool { return cmp(s[i], target) >= 0 })
+	return i, i < len(s) && cmp(s[i], target) == 0
+}
+
+// Returns a new slice with the elements of s, keeping only the first
+// occurrence of each. Returns nil if s is nil.
+func Dedupe[T comparable](s []T) []T {
+	return UniqueBy(s, func(t T) T { return t })
+}
+
+// Returns a new slice with the elements of s, keeping only the first element
+// with each key. Returns nil if s is nil.
+func UniqueBy[T any, K comparable](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+
+	seen := make(map[K]struct{}, len(s))
+	rv := make([]T, 0, len(s))
+	for _, t := range s {
+		k := key(t)
+		if _, exists := seen[k]; !exists {
+			seen[k] = struct{}{}
+			rv = append(rv, t)
+		}
+	}
+	return rv
+}
+
+// Merges slices that are each sorted by cmp into a single sorted slice.
+// Equivalent elements keep their relative order, with elements from earlier
+// slices first. Returns nil if every input slice is nil.
+func Merge[T any](cmp Comparator[T], sorted ...[]T) []T {
+	total := 0
+	allNil := true
+	for _, s := range sorted {
+		total += len(s)
+		allNil = allNil && s == nil
+	}
+	if allNil {
+		return nil
+	}
+
+	h := &mergeHeap[T]{cmp: cmp}
+	for i, s := range sorted {
+		if len(s) > 0 {
+			h.cursors = append(h.cursors, mergeCursor[T]{slice: s, source: i})
+		}
+	}
+	heap.Init(h)
+
+	rv := make([]T, 0, total)
+	for h.Len() > 0 {
+		c := &h.cursors[0]
+		rv = append(rv, c.slice[c.pos])
+		c.pos++
+		if c.pos == len(c.slice) {
+			heap.Pop(h)
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+	return rv
+}
+
+// The position reached in one of the slices being merged.
+type mergeCursor[T any] struct {
+	slice []T
+	pos   int
+
+	// The index of the slice among Merge's arguments, used to break ties.
+	source int
+}
+
+// A min-heap of cursors, ordered by their current elements.
+type mergeHeap[T any] struct {
+	cursors []mergeCursor[T]
+	cmp     Comparator[T]
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.cursors)
+}
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+	if rv := h.cmp(a.slice[a.pos], b.slice[b.pos]); rv != 0 {
+		return rv < 0
+	}
+	return a.source < b.source
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *mergeHeap[T]) Push(x interface{}) {
+	h.cursors = append(h.cursors, x.(mergeCursor[T]))
+}
+
+func (h *mergeHeap[T]) Pop() interface{} {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
@@ -0,0 +1,103 @@
+package slices
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortBy(t *testing.T) {
+	assert.Nil(t, SortBy(nil, Natural[int]()))
+	assert.Equal(t, []int{}, SortBy([]int{}, Natural[int]()))
+
+	input := []int{3, 1, 2}
+	assert.Equal(t, []int{1, 2, 3}, SortBy(input, Natural[int]()))
+	assert.Equal(t, []int{3, 2, 1}, SortBy(input, Natural[int]().Reversed()))
+	assert.Equal(t, []int{3, 1, 2}, input, "input is not modified")
+}
+
+func TestSortStableBy(t *testing.T) {
+	input := []string{"bb", "a", "cc", "b", "aa"}
+	byLength := CompareBy(func(s string) int { return len(s) })
+	assert.Equal(t, []string{"a", "b", "bb", "cc", "aa"}, SortStableBy(input, byLength))
+	assert.Equal(t, []string{"bb", "a", "cc", "b", "aa"}, input)
+}
+
+func TestSortedByKey(t *testing.T) {
+	assert.Nil(t, SortedByKey([]string(nil), strings.ToLower))
+
+	calls := 0
+	key := func(s string) string {
+		calls++
+		return strings.ToLower(s)
+	}
+	assert.Equal(t, []string{"a", "B", "b", "C"}, SortedByKey([]string{"C", "a", "B", "b"}, key))
+	assert.Equal(t, 4, calls)
+}
+
+func TestIsSortedBy(t *testing.T) {
+	assert.True(t, IsSortedBy([]int(nil), Natural[int]()))
+	assert.True(t, IsSortedBy([]int{1, 1, 2}, Natural[int]()))
+	assert.False(t, IsSortedBy([]int{1, 3, 2}, Natural[int]()))
+	assert.True(t, IsSortedBy([]int{3, 2, 2}, Natural[int]().Reversed()))
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	type entry struct {
+		key   int
+		value string
+	}
+	entries := []entry{{1, "a"}, {3, "b"}, {3, "c"}, {7, "d"}}
+	cmp := func(e entry, target int) int { return Natural[int]()(e.key, target) }
+
+	testCases := []struct {
+		target        int
+		expectedIndex int
+		expectedFound bool
+	}{
+		{0, 0, false},
+		{1, 0, true},
+		{3, 1, true},
+		{5, 3, false},
+		{7, 3, true},
+		{8, 4, false},
+	}
+	for _, tc := range testCases {
+		index, found := BinarySearchBy(entries, tc.target, cmp)
+		assert.Equal(t, tc.expectedIndex, index, tc.target)
+		assert.Equal(t, tc.expectedFound, found, tc.target)
+	}
+
+	index, found := BinarySearchBy([]entry(nil), 3, cmp)
+	assert.Equal(t, 0, index)
+	assert.False(t, found)
+}
+
+func TestDedupe(t *testing.T) {
+	assert.Nil(t, Dedupe([]int(nil)))
+	assert.Equal(t, []int{3, 1, 2}, Dedupe([]int{3, 1, 3, 2, 1}))
+	assert.Equal(t, []string{"a", "B"}, UniqueBy([]string{"a", "B", "A", "b"}, strings.ToLower))
+}
+
+func TestMerge(t *testing.T) {
+	assert.Nil(t, Merge[int](Natural[int]()))
+	assert.Nil(t, Merge(Natural[int](), nil, nil))
+	assert.Equal(t, []int{}, Merge(Natural[int](), []int{}, nil))
+	assert.Equal(t,
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		Merge(Natural[int](), []int{1, 4, 7}, []int{2, 5, 8}, nil, []int{3, 6, 9}))
+
+	// Ties keep elements from earlier slices first.
+	type tagged struct {
+		key    int
+		source string
+	}
+	byKey := CompareBy(func(t tagged) int { return t.key })
+	assert.Equal(t,
+		[]tagged{{1, "b"}, {2, "a"}, {2, "b"}, {2, "c"}, {3, "a"}},
+		Merge(byKey,
+			[]tagged{{2, "a"}, {3, "a"}},
+			[]tagged{{1, "b"}, {2, "b"}},
+			[]tagged{{2, "c"}}))
+}
@@ -0,0 +1,40 @@
+package slices
+
+import "github.com/akitasoftware/go-utils/tuples"
+
+// Pairs up the elements of a and b by index. If the slices have different
+// lengths, the extra elements of the longer one are ignored. Returns nil if
+// either slice is nil.
+func Zip[A, B any](a []A, b []B) []tuples.Pair[A, B] {
+	// Avoid creating an empty list if either input is nil.
+	if a == nil || b == nil {
+		return nil
+	}
+
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	rv := make([]tuples.Pair[A, B], n)
+	for i := range rv {
+		rv[i] = tuples.Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return rv
+}
+
+// Splits a slice of pairs into a slice of first elements and a slice of second
+// elements. Returns two nil slices if pairs is nil.
+func Unzip[A, B any](pairs []tuples.Pair[A, B]) ([]A, []B) {
+	// Avoid creating empty lists if pairs is nil.
+	if pairs == nil {
+		return nil, nil
+	}
+
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+	return as, bs
+}
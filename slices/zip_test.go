@@ -0,0 +1,26 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/tuples"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	assert.Nil(t, Zip([]int(nil), []string{"a"}))
+	assert.Equal(t, []tuples.Pair[int, string]{}, Zip([]int{}, []string{"a"}))
+	assert.Equal(t,
+		[]tuples.Pair[int, string]{tuples.NewPair(1, "a"), tuples.NewPair(2, "b")},
+		Zip([]int{1, 2, 3}, []string{"a", "b"}))
+}
+
+func TestUnzip(t *testing.T) {
+	as, bs := Unzip([]tuples.Pair[int, string](nil))
+	assert.Nil(t, as)
+	assert.Nil(t, bs)
+
+	as, bs = Unzip([]tuples.Pair[int, string]{tuples.NewPair(1, "a"), tuples.NewPair(2, "b")})
+	assert.Equal(t, []int{1, 2}, as)
+	assert.Equal(t, []string{"a", "b"}, bs)
+}
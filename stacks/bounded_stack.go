@@ -0,0 +1,128 @@
+package stacks
+
+import "github.com/akitasoftware/go-utils/optionals"
+
+// Determines what a BoundedStack does when an element is pushed while the
+// stack is at capacity.
+type OverflowPolicy int
+
+const (
+	// Discards the element at the bottom of the stack to make room for the new
+	// element.
+	DropOldest OverflowPolicy = iota
+
+	// Discards the new element, leaving the stack unchanged.
+	RejectNewest
+)
+
+// A stack that holds at most a fixed number of elements. Elements are stored in
+// a ring buffer that is allocated up front.
+type BoundedStack[T any] struct {
+	// Ring buffer holding the elements. Its length is the stack's capacity.
+	elements []T
+
+	// Index of the element at the bottom of the stack.
+	bottom int
+
+	// Number of elements on the stack.
+	size int
+
+	policy OverflowPolicy
+}
+
+// Returns a new stack that holds at most capacity elements, initialized by
+// pushing each of the given elements in turn. Panics if capacity is not
+// positive.
+func NewBoundedStack[T any](capacity int, policy OverflowPolicy, elements ...T) *BoundedStack[T] {
+	if capacity <= 0 {
+		panic("stacks: BoundedStack capacity must be positive")
+	}
+
+	rv := &BoundedStack[T]{
+		elements: make([]T, capacity),
+		policy:   policy,
+	}
+	for _, v := range elements {
+		rv.Push(v)
+	}
+	return rv
+}
+
+// Adds an element to the top of the stack. If the stack is full, the stack's
+// OverflowPolicy determines which element is discarded.
+func (stack *BoundedStack[T]) Push(element T) {
+	stack.TryPush(element)
+}
+
+// Like Push, but returns false if the element was rejected because the stack
+// is full. Always returns true under the DropOldest policy.
+func (stack *BoundedStack[T]) TryPush(element T) bool {
+	if stack.IsFull() {
+		if stack.policy == RejectNewest {
+			return false
+		}
+
+		// The slot after the top of a full stack is the bottom, so overwrite it
+		// and advance the bottom.
+		stack.elements[stack.bottom] = element
+		stack.bottom = stack.index(1)
+		return true
+	}
+
+	stack.elements[stack.index(stack.size)] = element
+	stack.size++
+	return true
+}
+
+func (stack *BoundedStack[T]) Pop() optionals.Optional[T] {
+	if stack.IsEmpty() {
+		return optionals.None[T]()
+	}
+
+	idx := stack.index(stack.size - 1)
+	result := stack.elements[idx]
+
+	// Clear the slot so that the element can be garbage collected.
+	var zero T
+	stack.elements[idx] = zero
+
+	stack.size--
+	return optionals.Some(result)
+}
+
+func (stack *BoundedStack[T]) Peek() optionals.Optional[T] {
+	if stack.IsEmpty() {
+		return optionals.None[T]()
+	}
+	return optionals.Some(stack.elements[stack.index(stack.size-1)])
+}
+
+func (stack *BoundedStack[T]) IsEmpty() bool {
+	return stack.Size() == 0
+}
+
+// Returns true if the stack holds as many elements as its capacity.
+func (stack *BoundedStack[T]) IsFull() bool {
+	return stack.Size() == stack.Capacity()
+}
+
+func (stack *BoundedStack[T]) Size() int {
+	return stack.size
+}
+
+// Returns the maximum number of elements the stack can hold.
+func (stack *BoundedStack[T]) Capacity() int {
+	return len(stack.elements)
+}
+
+func (stack *BoundedStack[T]) ForEach(f func(T)) {
+	for offset := stack.size - 1; offset >= 0; offset-- {
+		f(stack.elements[stack.index(offset)])
+	}
+}
+
+// Converts an offset from the bottom of the stack into an index into the ring
+// buffer.
+func (stack *BoundedStack[T]) index(offset int) int {
+	return (stack.bottom + offset) % len(stack.elements)
+}
@@ -0,0 +1,70 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedStackOverflow(t *testing.T) {
+	testCases := []struct {
+		name             string
+		policy           OverflowPolicy
+		input            []int
+		expectedAccepted []bool
+		expectedPopped   []int
+	}{
+		{
+			name:             "drop oldest",
+			policy:           DropOldest,
+			input:            []int{1, 2, 3, 4, 5},
+			expectedAccepted: []bool{true, true, true, true, true},
+			expectedPopped:   []int{5, 4, 3},
+		},
+		{
+			name:             "reject newest",
+			policy:           RejectNewest,
+			input:            []int{1, 2, 3, 4, 5},
+			expectedAccepted: []bool{true, true, true, false, false},
+			expectedPopped:   []int{3, 2, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		stack := NewBoundedStack[int](3, tc.policy)
+
+		accepted := make([]bool, 0, len(tc.input))
+		for _, v := range tc.input {
+			accepted = append(accepted, stack.TryPush(v))
+		}
+		assert.Equal(t, tc.expectedAccepted, accepted, tc.name)
+		assert.True(t, stack.IsFull(), tc.name)
+		assert.Equal(t, 3, stack.Size(), tc.name)
+
+		var foreachOutput []int
+		stack.ForEach(func(v int) {
+			foreachOutput = append(foreachOutput, v)
+		})
+		assert.Equal(t, tc.expectedPopped, foreachOutput, tc.name)
+
+		var popped []int
+		for v, exists := stack.Pop().Get(); exists; v, exists = stack.Pop().Get() {
+			popped = append(popped, v)
+		}
+		assert.Equal(t, tc.expectedPopped, popped, tc.name)
+		assert.Equal(t, optionals.None[int](), stack.Peek(), tc.name)
+	}
+}
+
+func TestBoundedStackWraparound(t *testing.T) {
+	stack := NewBoundedStack(2, DropOldest, 1, 2, 3)
+	assert.Equal(t, optionals.Some(3), stack.Pop())
+
+	// Push into the slot freed by the pop, then overflow again.
+	stack.Push(4)
+	stack.Push(5)
+	assert.Equal(t, optionals.Some(5), stack.Pop())
+	assert.Equal(t, optionals.Some(4), stack.Pop())
+	assert.True(t, stack.IsEmpty())
+}
@@ -0,0 +1,62 @@
+package stacks
+
+import (
+	"sync"
+
+	"github.com/akitasoftware/go-utils/optionals"
+)
+
+// A stack that is safe for concurrent use by multiple goroutines. Each
+// operation holds a mutex for its duration.
+type ConcurrentStack[T any] struct {
+	mu    sync.Mutex
+	stack *LinkedStack[T]
+}
+
+func NewConcurrentStack[T any](elements ...T) *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{
+		stack: NewLinkedStack(elements...),
+	}
+}
+
+func (stack *ConcurrentStack[T]) Push(element T) {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	stack.stack.Push(element)
+}
+
+func (stack *ConcurrentStack[T]) Pop() optionals.Optional[T] {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	return stack.stack.Pop()
+}
+
+func (stack *ConcurrentStack[T]) Peek() optionals.Optional[T] {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	return stack.stack.Peek()
+}
+
+func (stack *ConcurrentStack[T]) IsEmpty() bool {
+	return stack.Size() == 0
+}
+
+func (stack *ConcurrentStack[T]) Size() int {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	return stack.stack.Size()
+}
+
+// Calls f on a snapshot of the stack's elements, from top to bottom. The lock
+// is not held while f runs, so f may safely operate on the stack.
+func (stack *ConcurrentStack[T]) ForEach(f func(T)) {
+	stack.mu.Lock()
+	// LinkedStack nodes are never modified after being pushed, so the top node
+	// is a consistent snapshot of the stack.
+	top := stack.stack.top
+	stack.mu.Unlock()
+
+	for node := top; node != nil; node = node.next {
+		f(node.value)
+	}
+}
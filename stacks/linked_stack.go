@@ -0,0 +1,63 @@
+package stacks
+
+import "github.com/akitasoftware/go-utils/optionals"
+
+type linkedStackNode[T any] struct {
+	value T
+	next  *linkedStackNode[T]
+}
+
+// A stack backed by a singly linked list. Unlike SliceStack, memory is released
+// as elements are popped.
+type LinkedStack[T any] struct {
+	top  *linkedStackNode[T]
+	size int
+}
+
+func NewLinkedStack[T any](elements ...T) *LinkedStack[T] {
+	rv := &LinkedStack[T]{}
+	for _, v := range elements {
+		rv.Push(v)
+	}
+	return rv
+}
+
+func (stack *LinkedStack[T]) Push(element T) {
+	stack.top = &linkedStackNode[T]{
+		value: element,
+		next:  stack.top,
+	}
+	stack.size++
+}
+
+func (stack *LinkedStack[T]) Pop() optionals.Optional[T] {
+	if stack.top == nil {
+		return optionals.None[T]()
+	}
+
+	result := stack.top.value
+	stack.top = stack.top.next
+	stack.size--
+	return optionals.Some(result)
+}
+
+func (stack *LinkedStack[T]) Peek() optionals.Optional[T] {
+	if stack.top == nil {
+		return optionals.None[T]()
+	}
+	return optionals.Some(stack.top.value)
+}
+
+func (stack *LinkedStack[T]) IsEmpty() bool {
+	return stack.Size() == 0
+}
+
+func (stack *LinkedStack[T]) Size() int {
+	return stack.size
+}
+
+func (stack *LinkedStack[T]) ForEach(f func(T)) {
+	for node := stack.top; node != nil; node = node.next {
+		f(node.value)
+	}
+}
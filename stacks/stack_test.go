@@ -1,68 +1,84 @@
-package stacks
+package stacks_test
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
-	"github.com/akitasoftware/go-utils/slices"
+	"github.com/akitasoftware/go-utils/stacks"
+	"github.com/akitasoftware/go-utils/stacks/stacktest"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestStack(t *testing.T) {
-	tests := []struct {
-		name  string
-		input []int
+	implementations := []struct {
+		name     string
+		newStack func() stacks.Stack[int]
 	}{
 		{
-			name:  "empty",
-			input: []int{},
+			name:     "SliceStack",
+			newStack: func() stacks.Stack[int] { return stacks.NewSliceStack[int]() },
 		},
 		{
-			name:  "singleton",
-			input: []int{1},
+			name:     "LinkedStack",
+			newStack: func() stacks.Stack[int] { return stacks.NewLinkedStack[int]() },
 		},
 		{
-			name:  "list",
-			input: []int{1, 2, 3, 4, 5},
+			name: "BoundedStack",
+			newStack: func() stacks.Stack[int] {
+				return stacks.NewBoundedStack[int](stacktest.NumRandomOps, stacks.RejectNewest)
+			},
+		},
+		{
+			name:     "ConcurrentStack",
+			newStack: func() stacks.Stack[int] { return stacks.NewConcurrentStack[int]() },
+		},
+		{
+			name:     "TreiberStack",
+			newStack: func() stacks.Stack[int] { return stacks.NewTreiberStack[int]() },
 		},
 	}
 
-	for _, tc := range tests {
-		for i, q := range []Stack[int]{NewSliceStack[int]()} {
-			label := func(msg string) string {
-				return fmt.Sprintf("%s - %d: %s", tc.name, i, msg)
-			}
-
-			assert.True(t, q.IsEmpty(), label("empty"))
-
-			for _, v := range tc.input {
-				q.Push(v)
-			}
-			reversedInput := slices.Reverse(tc.input)
-
-			assert.Equal(t, len(tc.input), q.Size(), label("length"))
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			stacktest.Run(t, impl.newStack)
+		})
+	}
+}
 
-			if len(tc.input) > 0 {
-				assert.False(t, q.IsEmpty(), label("not empty"))
-			}
+func TestConcurrentStacks(t *testing.T) {
+	const numGoroutines = 8
+	const numPushes = 1000
 
-			// Test ForEach. It should behave as if we had popped each element.
-			foreachOutput := make([]int, 0, q.Size())
-			q.ForEach(func(v int) {
-				foreachOutput = append(foreachOutput, v)
-			})
-			assert.Equal(t, reversedInput, foreachOutput, label("foreach output"))
+	for i, q := range []stacks.Stack[int]{stacks.NewConcurrentStack[int](), stacks.NewTreiberStack[int]()} {
+		label := func(msg string) string {
+			return fmt.Sprintf("%d: %s", i, msg)
+		}
 
-			// Pop all elements, and check that the resulting list is equal to the
-			// input, reversed.
-			output := make([]int, q.Size())
-			for i := range output {
-				if v, exists := q.Pop().Get(); exists {
-					output[i] = v
+		// Push from several goroutines at once, popping every other element.
+		var wg sync.WaitGroup
+		for g := 0; g < numGoroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < numPushes; j++ {
+					q.Push(j)
+					if j%2 == 1 {
+						q.Pop()
+					}
 				}
-			}
+			}()
+		}
+		wg.Wait()
+
+		expectedSize := numGoroutines * numPushes / 2
+		assert.Equal(t, expectedSize, q.Size(), label("size"))
 
-			assert.Equal(t, reversedInput, output, label("output"))
+		popped := 0
+		for q.Pop().IsSome() {
+			popped++
 		}
+		assert.Equal(t, expectedSize, popped, label("popped"))
+		assert.True(t, q.IsEmpty(), label("empty"))
 	}
 }
@@ -0,0 +1,162 @@
+// Package stacktest provides a behavioral test suite for implementations of
+// stacks.Stack.
+package stacktest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/akitasoftware/go-utils/slices"
+	"github.com/akitasoftware/go-utils/stacks"
+	"github.com/stretchr/testify/assert"
+)
+
+// The number of random operations performed by each model-based check. Stacks
+// under test must be able to hold at least this many elements.
+const NumRandomOps = 500
+
+// Seeds used by the model-based checks. Fixed so that failures are
+// reproducible.
+var seeds = []int64{1, 2, 3, 4, 5}
+
+// Runs a suite of behavioral tests against a Stack implementation. Each call to
+// newStack must return a new, empty stack.
+func Run(t *testing.T, newStack func() stacks.Stack[int]) {
+	t.Run("PushPop", func(t *testing.T) {
+		testPushPop(t, newStack)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		testEmpty(t, newStack())
+	})
+	t.Run("Model", func(t *testing.T) {
+		for _, seed := range seeds {
+			testModel(t, newStack(), seed)
+		}
+	})
+}
+
+func testPushPop(t *testing.T, newStack func() stacks.Stack[int]) {
+	tests := []struct {
+		name  string
+		input []int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+		},
+		{
+			name:  "singleton",
+			input: []int{1},
+		},
+		{
+			name:  "list",
+			input: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range tests {
+		q := newStack()
+		label := func(msg string) string {
+			return fmt.Sprintf("%s: %s", tc.name, msg)
+		}
+
+		assert.True(t, q.IsEmpty(), label("empty"))
+
+		for _, v := range tc.input {
+			q.Push(v)
+		}
+		reversedInput := slices.Reverse(tc.input)
+
+		assert.Equal(t, len(tc.input), q.Size(), label("length"))
+
+		if len(tc.input) > 0 {
+			assert.False(t, q.IsEmpty(), label("not empty"))
+		}
+
+		// Test ForEach. It should behave as if we had popped each element.
+		foreachOutput := make([]int, 0, q.Size())
+		q.ForEach(func(v int) {
+			foreachOutput = append(foreachOutput, v)
+		})
+		assert.Equal(t, reversedInput, foreachOutput, label("foreach output"))
+
+		// Pop all elements, and check that the resulting list is equal to the
+		// input, reversed.
+		output := make([]int, q.Size())
+		for i := range output {
+			if v, exists := q.Pop().Get(); exists {
+				output[i] = v
+			}
+		}
+
+		assert.Equal(t, reversedInput, output, label("output"))
+		assert.True(t, q.IsEmpty(), label("empty after pop"))
+	}
+}
+
+func testEmpty(t *testing.T, q stacks.Stack[int]) {
+	assert.True(t, q.IsEmpty())
+	assert.Equal(t, 0, q.Size())
+	assert.Equal(t, optionals.None[int](), q.Peek())
+	assert.Equal(t, optionals.None[int](), q.Pop())
+
+	// Popping an empty stack must leave it usable.
+	q.Push(1)
+	assert.Equal(t, optionals.Some(1), q.Peek())
+	assert.Equal(t, optionals.Some(1), q.Pop())
+	assert.True(t, q.IsEmpty())
+}
+
+// Performs a random sequence of operations on q, checking after each one that q
+// agrees with a reference stack modelled as a slice whose last element is the
+// top.
+func testModel(t *testing.T, q stacks.Stack[int], seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	model := []int{}
+
+	top := func() optionals.Optional[int] {
+		if len(model) == 0 {
+			return optionals.None[int]()
+		}
+		return optionals.Some(model[len(model)-1])
+	}
+
+	for i := 0; i < NumRandomOps; i++ {
+		label := func(msg string) string {
+			return fmt.Sprintf("seed %d, op %d: %s", seed, i, msg)
+		}
+
+		switch op := rng.Intn(4); op {
+		case 0, 1:
+			// Bias towards pushes so that the stack grows.
+			v := rng.Int()
+			q.Push(v)
+			model = append(model, v)
+		case 2:
+			expected := top()
+			if len(model) > 0 {
+				model = model[:len(model)-1]
+			}
+			if !assert.Equal(t, expected, q.Pop(), label("pop")) {
+				return
+			}
+		case 3:
+			if !assert.Equal(t, top(), q.Peek(), label("peek")) {
+				return
+			}
+		}
+
+		if !assert.Equal(t, len(model), q.Size(), label("size")) {
+			return
+		}
+		assert.Equal(t, len(model) == 0, q.IsEmpty(), label("is empty"))
+	}
+
+	foreachOutput := []int{}
+	q.ForEach(func(v int) {
+		foreachOutput = append(foreachOutput, v)
+	})
+	assert.Equal(t, slices.Reverse(model), foreachOutput, fmt.Sprintf("seed %d: foreach output", seed))
+}
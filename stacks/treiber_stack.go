@@ -0,0 +1,87 @@
+package stacks
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/akitasoftware/go-utils/optionals"
+)
+
+// A lock-free stack that is safe for concurrent use by multiple goroutines,
+// using Treiber's algorithm. Under contention, operations retry rather than
+// block.
+//
+// Because nodes are never reused while reachable, the garbage collector rules
+// out the ABA problem.
+type TreiberStack[T any] struct {
+	// Accessed with 64-bit atomic operations, so it must stay the first field:
+	// on 32-bit platforms, only the first word of an allocated struct is
+	// guaranteed to be 64-bit aligned.
+	size int64
+
+	// Points to the linkedStackNode[T] at the top of the stack.
+	top unsafe.Pointer
+}
+
+func NewTreiberStack[T any](elements ...T) *TreiberStack[T] {
+	rv := &TreiberStack[T]{}
+	for _, v := range elements {
+		rv.Push(v)
+	}
+	return rv
+}
+
+func (stack *TreiberStack[T]) Push(element T) {
+	node := &linkedStackNode[T]{value: element}
+	for {
+		top := atomic.LoadPointer(&stack.top)
+		node.next = (*linkedStackNode[T])(top)
+		if atomic.CompareAndSwapPointer(&stack.top, top, unsafe.Pointer(node)) {
+			atomic.AddInt64(&stack.size, 1)
+			return
+		}
+	}
+}
+
+func (stack *TreiberStack[T]) Pop() optionals.Optional[T] {
+	for {
+		top := atomic.LoadPointer(&stack.top)
+		if top == nil {
+			return optionals.None[T]()
+		}
+
+		node := (*linkedStackNode[T])(top)
+		if atomic.CompareAndSwapPointer(&stack.top, top, unsafe.Pointer(node.next)) {
+			atomic.AddInt64(&stack.size, -1)
+			return optionals.Some(node.value)
+		}
+	}
+}
+
+func (stack *TreiberStack[T]) Peek() optionals.Optional[T] {
+	top := (*linkedStackNode[T])(atomic.LoadPointer(&stack.top))
+	if top == nil {
+		return optionals.None[T]()
+	}
+	return optionals.Some(top.value)
+}
+
+func (stack *TreiberStack[T]) IsEmpty() bool {
+	return atomic.LoadPointer(&stack.top) == nil
+}
+
+// Returns the number of elements on the stack. Under concurrent modification,
+// the result may briefly lag behind the stack's contents.
+func (stack *TreiberStack[T]) Size() int {
+	if size := atomic.LoadInt64(&stack.size); size > 0 {
+		return int(size)
+	}
+	return 0
+}
+
+// Calls f on a snapshot of the stack's elements, from top to bottom.
+func (stack *TreiberStack[T]) ForEach(f func(T)) {
+	for node := (*linkedStackNode[T])(atomic.LoadPointer(&stack.top)); node != nil; node = node.next {
+		f(node.value)
+	}
+}
@@ -0,0 +1,15 @@
+package stacks
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// On 32-bit platforms, 64-bit atomic operations panic unless the field is
+// 64-bit aligned, which is only guaranteed for the first field.
+func TestTreiberStackSizeIsFirst(t *testing.T) {
+	var stack TreiberStack[int]
+	assert.Zero(t, unsafe.Offsetof(stack.size))
+}
@@ -0,0 +1,158 @@
+package tuples
+
+import (
+	"encoding/json"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/pkg/errors"
+)
+
+// Holds either a value of type L or a value of type R. By convention, Right
+// holds the expected value and Left holds the alternative, such as an error.
+// Eithers are comparable when both of their type parameters are.
+//
+// The zero value is a Left holding the zero value of L.
+//
+// Eithers marshal to JSON and YAML as an object with a single key, "left" or
+// "right", holding the value.
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+func Left[L, R any](l L) Either[L, R] {
+	return Either[L, R]{left: l}
+}
+
+func Right[L, R any](r R) Either[L, R] {
+	return Either[L, R]{right: r, isRight: true}
+}
+
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Returns the left value, or None if e is a Right.
+func (e Either[L, R]) GetLeft() optionals.Optional[L] {
+	if e.isRight {
+		return optionals.None[L]()
+	}
+	return optionals.Some(e.left)
+}
+
+// Returns the right value, or None if e is a Left.
+func (e Either[L, R]) GetRight() optionals.Optional[R] {
+	if e.isRight {
+		return optionals.Some(e.right)
+	}
+	return optionals.None[R]()
+}
+
+// Turns a Left into a Right and vice versa.
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// Applies f to the value of e if it is a Left.
+func MapLeft[L, R, M any](e Either[L, R], f func(L) M) Either[M, R] {
+	if e.isRight {
+		return Right[M](e.right)
+	}
+	return Left[M, R](f(e.left))
+}
+
+// Applies f to the value of e if it is a Right.
+func MapRight[L, R, S any](e Either[L, R], f func(R) S) Either[L, S] {
+	if e.isRight {
+		return Right[L](f(e.right))
+	}
+	return Left[L, S](e.left)
+}
+
+// Returns onLeft applied to the value of e if it is a Left, and onRight
+// applied to the value of e otherwise.
+func Fold[L, R, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+const (
+	leftKey  = "left"
+	rightKey = "right"
+)
+
+func (e Either[L, R]) MarshalJSON() ([]byte, error) {
+	if e.isRight {
+		return json.Marshal(map[string]interface{}{rightKey: e.right})
+	}
+	return json.Marshal(map[string]interface{}{leftKey: e.left})
+}
+
+func (e *Either[L, R]) UnmarshalJSON(data []byte) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal Either")
+	}
+
+	key, value, err := singleEntry(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal Either")
+	}
+
+	*e = Either[L, R]{isRight: key == rightKey}
+	if e.isRight {
+		err = json.Unmarshal(value, &e.right)
+	} else {
+		err = json.Unmarshal(value, &e.left)
+	}
+	return errors.Wrapf(err, "failed to unmarshal %s value of Either", key)
+}
+
+func (e Either[L, R]) MarshalYAML() (interface{}, error) {
+	if e.isRight {
+		return map[string]interface{}{rightKey: e.right}, nil
+	}
+	return map[string]interface{}{leftKey: e.left}, nil
+}
+
+func (e *Either[L, R]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var obj map[string]yamlElement
+	if err := unmarshal(&obj); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal Either")
+	}
+
+	key, value, err := singleEntry(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal Either")
+	}
+
+	*e = Either[L, R]{isRight: key == rightKey}
+	if e.isRight {
+		err = value.decode(&e.right)
+	} else {
+		err = value.decode(&e.left)
+	}
+	return errors.Wrapf(err, "failed to unmarshal %s value of Either", key)
+}
+
+// Returns the only entry in obj, which must be keyed by "left" or "right".
+func singleEntry[V any](obj map[string]V) (string, V, error) {
+	var zero V
+	if len(obj) != 1 {
+		return "", zero, errors.Errorf(`expected an object with a single "left" or "right" key, but got %d keys`, len(obj))
+	}
+	for key, value := range obj {
+		if key != leftKey && key != rightKey {
+			return "", zero, errors.Errorf(`expected key "left" or "right", but got %q`, key)
+		}
+		return key, value, nil
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,94 @@
+package tuples
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/optionals"
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestEither(t *testing.T) {
+	l := Left[string, int]("error")
+	r := Right[string](42)
+
+	assert.True(t, l.IsLeft())
+	assert.False(t, l.IsRight())
+	assert.True(t, r.IsRight())
+	assert.Equal(t, optionals.Some("error"), l.GetLeft())
+	assert.Equal(t, optionals.None[int](), l.GetRight())
+	assert.Equal(t, optionals.None[string](), r.GetLeft())
+	assert.Equal(t, optionals.Some(42), r.GetRight())
+
+	assert.Equal(t, Right[int]("error"), l.Swap())
+	assert.Equal(t, Left[int, string](42), r.Swap())
+
+	assert.Equal(t, Right[string]("42"), MapRight(r, strconv.Itoa))
+	assert.Equal(t, Left[string, string]("error"), MapRight(l, strconv.Itoa))
+	assert.Equal(t, Left[int, int](5), MapLeft(l, func(s string) int { return len(s) }))
+	assert.Equal(t, Right[int](42), MapLeft(r, func(s string) int { return len(s) }))
+
+	describe := func(e Either[string, int]) string {
+		return Fold(e, func(s string) string { return "left " + s }, func(i int) string { return "right " + strconv.Itoa(i) })
+	}
+	assert.Equal(t, "left error", describe(l))
+	assert.Equal(t, "right 42", describe(r))
+
+	// The zero value is a Left.
+	var zero Either[string, int]
+	assert.Equal(t, Left[string, int](""), zero)
+
+	// Eithers are comparable, and Lefts and Rights are distinct.
+	s := sets.NewSet(Left[int, int](1), Right[int](1), Left[int, int](1))
+	assert.Equal(t, 2, s.Size())
+}
+
+func TestEitherJSON(t *testing.T) {
+	testCases := []struct {
+		either Either[string, int]
+		json   string
+	}{
+		{Left[string, int]("error"), `{"left":"error"}`},
+		{Right[string](42), `{"right":42}`},
+	}
+	for _, tc := range testCases {
+		serialized, err := json.Marshal(tc.either)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.json, string(serialized))
+
+		var deserialized Either[string, int]
+		assert.NoError(t, json.Unmarshal(serialized, &deserialized))
+		assert.Equal(t, tc.either, deserialized)
+	}
+
+	var deserialized Either[string, int]
+	for _, invalid := range []string{`{}`, `{"left":"a","right":1}`, `{"middle":1}`, `{"right":"a"}`, `[1]`} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &deserialized), invalid)
+	}
+}
+
+func TestEitherYAML(t *testing.T) {
+	testCases := []struct {
+		either Either[string, int]
+		yaml   string
+	}{
+		{Left[string, int]("error"), "left: error\n"},
+		{Right[string](42), "right: 42\n"},
+	}
+	for _, tc := range testCases {
+		serialized, err := yaml.Marshal(tc.either)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.yaml, string(serialized))
+
+		var deserialized Either[string, int]
+		assert.NoError(t, yaml.Unmarshal(serialized, &deserialized))
+		assert.Equal(t, tc.either, deserialized)
+	}
+
+	var deserialized Either[string, int]
+	assert.Error(t, yaml.Unmarshal([]byte("middle: 1\n"), &deserialized))
+	assert.Error(t, yaml.Unmarshal([]byte("right: a\n"), &deserialized))
+}
@@ -0,0 +1,50 @@
+package tuples
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Splits a JSON array that must have exactly n elements.
+func unmarshalJSONArray(data []byte, n int) ([]json.RawMessage, error) {
+	var elts []json.RawMessage
+	if err := json.Unmarshal(data, &elts); err != nil {
+		return nil, err
+	}
+	if len(elts) != n {
+		return nil, errors.Errorf("expected an array of %d elements, but got %d", n, len(elts))
+	}
+	return elts, nil
+}
+
+// Captures a YAML node so it can be decoded later, once its type is known.
+type yamlElement struct {
+	unmarshal func(interface{}) error
+}
+
+func (e *yamlElement) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	e.unmarshal = unmarshal
+	return nil
+}
+
+// Decodes the captured node into v. yaml.v2 doesn't call unmarshalers for null
+// nodes, so a null leaves v unchanged.
+func (e yamlElement) decode(v interface{}) error {
+	if e.unmarshal == nil {
+		return nil
+	}
+	return e.unmarshal(v)
+}
+
+// Splits a YAML sequence that must have exactly n elements.
+func unmarshalYAMLSequence(unmarshal func(interface{}) error, n int) ([]yamlElement, error) {
+	var elts []yamlElement
+	if err := unmarshal(&elts); err != nil {
+		return nil, err
+	}
+	if len(elts) != n {
+		return nil, errors.Errorf("expected a sequence of %d elements, but got %d", n, len(elts))
+	}
+	return elts, nil
+}
@@ -0,0 +1,81 @@
+package tuples
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// A pair of values. Pairs are comparable when both of their type parameters
+// are, so they can be used as map keys and set elements.
+//
+// Pairs marshal to JSON and YAML as two-element arrays.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Returns both elements of the pair.
+func (p Pair[A, B]) Get() (A, B) {
+	return p.First, p.Second
+}
+
+// Returns a pair with the elements of p in the opposite order.
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{First: p.Second, Second: p.First}
+}
+
+// Applies f to the first element of p.
+func MapFirst[A, B, C any](p Pair[A, B], f func(A) C) Pair[C, B] {
+	return Pair[C, B]{First: f(p.First), Second: p.Second}
+}
+
+// Applies f to the second element of p.
+func MapSecond[A, B, C any](p Pair[A, B], f func(B) C) Pair[A, C] {
+	return Pair[A, C]{First: p.First, Second: f(p.Second)}
+}
+
+// Applies f to the first element of p, and g to the second.
+func MapPair[A, B, C, D any](p Pair[A, B], f func(A) C, g func(B) D) Pair[C, D] {
+	return Pair[C, D]{First: f(p.First), Second: g(p.Second)}
+}
+
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.First, p.Second})
+}
+
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	elts, err := unmarshalJSONArray(data, 2)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal pair")
+	}
+	if err := json.Unmarshal(elts[0], &p.First); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal first element of pair")
+	}
+	if err := json.Unmarshal(elts[1], &p.Second); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal second element of pair")
+	}
+	return nil
+}
+
+func (p Pair[A, B]) MarshalYAML() (interface{}, error) {
+	return []interface{}{p.First, p.Second}, nil
+}
+
+func (p *Pair[A, B]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	elts, err := unmarshalYAMLSequence(unmarshal, 2)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal pair")
+	}
+	if err := elts[0].decode(&p.First); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal first element of pair")
+	}
+	if err := elts[1].decode(&p.Second); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal second element of pair")
+	}
+	return nil
+}
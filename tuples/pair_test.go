@@ -0,0 +1,68 @@
+package tuples
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/akitasoftware/go-utils/maps"
+	"github.com/akitasoftware/go-utils/sets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPair(t *testing.T) {
+	p := NewPair("a", 1)
+	first, second := p.Get()
+	assert.Equal(t, "a", first)
+	assert.Equal(t, 1, second)
+
+	assert.Equal(t, NewPair(1, "a"), p.Swap())
+	assert.Equal(t, NewPair("aa", 1), MapFirst(p, func(s string) string { return s + s }))
+	assert.Equal(t, NewPair("a", "1"), MapSecond(p, strconv.Itoa))
+	assert.Equal(t, NewPair(1, "1"), MapPair(p, func(s string) int { return len(s) }, strconv.Itoa))
+}
+
+func TestPairAsKey(t *testing.T) {
+	s := sets.NewSet(NewPair("GET", "/a"), NewPair("GET", "/a"), NewPair("POST", "/a"))
+	assert.Equal(t, 2, s.Size())
+	assert.True(t, s.Contains(NewPair("POST", "/a")))
+
+	m := maps.NewComplexKeyMap[Pair[string, string], int]()
+	m.Put(NewPair("GET", "/a"), 1)
+	assert.Equal(t, 1, m.GetOrDefault(NewPair("GET", "/a")))
+}
+
+func TestPairJSON(t *testing.T) {
+	p := NewPair("a", []int{1, 2})
+	serialized, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a",[1,2]]`, string(serialized))
+
+	var deserialized Pair[string, []int]
+	assert.NoError(t, json.Unmarshal(serialized, &deserialized))
+	assert.Equal(t, p, deserialized)
+
+	for _, invalid := range []string{`["a"]`, `["a",[1],3]`, `{"First":"a"}`, `[1,[1]]`} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &deserialized), invalid)
+	}
+}
+
+func TestPairYAML(t *testing.T) {
+	p := NewPair("a", 1)
+	serialized, err := yaml.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, "- a\n- 1\n", string(serialized))
+
+	var deserialized Pair[string, int]
+	assert.NoError(t, yaml.Unmarshal(serialized, &deserialized))
+	assert.Equal(t, p, deserialized)
+
+	// A null element decodes as the zero value.
+	var withNull Pair[string, *int]
+	assert.NoError(t, yaml.Unmarshal([]byte("[a, null]"), &withNull))
+	assert.Equal(t, NewPair[string, *int]("a", nil), withNull)
+
+	assert.Error(t, yaml.Unmarshal([]byte("[a]"), &deserialized))
+	assert.Error(t, yaml.Unmarshal([]byte("[a, b]"), &deserialized))
+}
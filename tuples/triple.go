@@ -0,0 +1,62 @@
+package tuples
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// A triple of values. Triples are comparable when all of their type
+// parameters are, so they can be used as map keys and set elements.
+//
+// Triples marshal to JSON and YAML as three-element arrays.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+func NewTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Returns all three elements of the triple.
+func (t Triple[A, B, C]) Get() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+func (t Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{t.First, t.Second, t.Third})
+}
+
+func (t *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	elts, err := unmarshalJSONArray(data, 3)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal triple")
+	}
+	targets := []interface{}{&t.First, &t.Second, &t.Third}
+	for i, target := range targets {
+		if err := json.Unmarshal(elts[i], target); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal element %d of triple", i)
+		}
+	}
+	return nil
+}
+
+func (t Triple[A, B, C]) MarshalYAML() (interface{}, error) {
+	return []interface{}{t.First, t.Second, t.Third}, nil
+}
+
+func (t *Triple[A, B, C]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	elts, err := unmarshalYAMLSequence(unmarshal, 3)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal triple")
+	}
+	targets := []interface{}{&t.First, &t.Second, &t.Third}
+	for i, target := range targets {
+		if err := elts[i].decode(target); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal element %d of triple", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package tuples
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTriple(t *testing.T) {
+	tr := NewTriple("a", 1, true)
+	first, second, third := tr.Get()
+	assert.Equal(t, "a", first)
+	assert.Equal(t, 1, second)
+	assert.Equal(t, true, third)
+
+	// Triples are comparable.
+	m := map[Triple[string, int, bool]]int{tr: 1}
+	assert.Equal(t, 1, m[NewTriple("a", 1, true)])
+}
+
+func TestTripleEncoding(t *testing.T) {
+	tr := NewTriple("a", 1, true)
+
+	serialized, err := json.Marshal(tr)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a",1,true]`, string(serialized))
+
+	var fromJSON Triple[string, int, bool]
+	assert.NoError(t, json.Unmarshal(serialized, &fromJSON))
+	assert.Equal(t, tr, fromJSON)
+	assert.Error(t, json.Unmarshal([]byte(`["a",1]`), &fromJSON))
+
+	serialized, err = yaml.Marshal(tr)
+	assert.NoError(t, err)
+	assert.Equal(t, "- a\n- 1\n- true\n", string(serialized))
+
+	var fromYAML Triple[string, int, bool]
+	assert.NoError(t, yaml.Unmarshal(serialized, &fromYAML))
+	assert.Equal(t, tr, fromYAML)
+}